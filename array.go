@@ -1,12 +1,30 @@
 package go_objectutils
 
 import (
-	"fmt"
+	"reflect"
 	"time"
 )
 
+// toInterfaceSlice converts any slice or array value (including named slice
+// types and fixed size arrays) into a []interface{} so that elements can be
+// converted using the scalar rules.
+func toInterfaceSlice(val interface{}) ([]interface{}, bool) {
+	if arr, ok := val.([]interface{}); ok {
+		return arr, true
+	}
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	res := make([]interface{}, rv.Len())
+	for i := range res {
+		res[i] = rv.Index(i).Interface()
+	}
+	return res, true
+}
+
 // GetStringArray retrieves a string array property.
-func GetStringArray(props map[string]interface{}, prop string) ([]string, error) {
+func GetStringArray(props map[string]interface{}, prop string, opts ...GetOptions) ([]string, error) {
 	if props == nil {
 		return nil, &MissingFieldError{Prop: prop}
 	}
@@ -15,12 +33,12 @@ func GetStringArray(props map[string]interface{}, prop string) ([]string, error)
 		return nil, &MissingFieldError{Prop: prop}
 	}
 	if arr, ok := val.([]string); ok {
-		return arr, nil
+		return copyResult(arr, opts), nil
 	}
-	if arr, ok := val.([]interface{}); ok {
+	if arr, ok := toInterfaceSlice(val); ok {
 		res := make([]string, len(arr))
 		for i, v := range arr {
-			if s, ok := v.(string); ok {
+			if s, err := convertToString(v); err == nil {
 				res[i] = s
 			} else {
 				return nil, &InvalidTypeError{Prop: prop, Expected: "string element", Actual: v}
@@ -32,8 +50,8 @@ func GetStringArray(props map[string]interface{}, prop string) ([]string, error)
 }
 
 // MustGetStringArray retrieves a string array property or panics.
-func MustGetStringArray(props map[string]interface{}, prop string) []string {
-	val, err := GetStringArray(props, prop)
+func MustGetStringArray(props map[string]interface{}, prop string, opts ...GetOptions) []string {
+	val, err := GetStringArray(props, prop, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -41,8 +59,8 @@ func MustGetStringArray(props map[string]interface{}, prop string) []string {
 }
 
 // GetStringArrayOrDefault retrieves a string array property or returns a default value.
-func GetStringArrayOrDefault(props map[string]interface{}, prop string, defaultValue []string) []string {
-	val, err := GetStringArray(props, prop)
+func GetStringArrayOrDefault(props map[string]interface{}, prop string, defaultValue []string, opts ...GetOptions) []string {
+	val, err := GetStringArray(props, prop, opts...)
 	if err != nil {
 		return defaultValue
 	}
@@ -50,8 +68,8 @@ func GetStringArrayOrDefault(props map[string]interface{}, prop string, defaultV
 }
 
 // GetStringArrayPtr retrieves a string array property as a pointer.
-func GetStringArrayPtr(props map[string]interface{}, prop string) (*[]string, error) {
-	val, err := GetStringArray(props, prop)
+func GetStringArrayPtr(props map[string]interface{}, prop string, opts ...GetOptions) (*[]string, error) {
+	val, err := GetStringArray(props, prop, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -59,8 +77,8 @@ func GetStringArrayPtr(props map[string]interface{}, prop string) (*[]string, er
 }
 
 // MustGetStringArrayPtr retrieves a string array property as a pointer or panics.
-func MustGetStringArrayPtr(props map[string]interface{}, prop string) *[]string {
-	val, err := GetStringArrayPtr(props, prop)
+func MustGetStringArrayPtr(props map[string]interface{}, prop string, opts ...GetOptions) *[]string {
+	val, err := GetStringArrayPtr(props, prop, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -68,8 +86,8 @@ func MustGetStringArrayPtr(props map[string]interface{}, prop string) *[]string
 }
 
 // GetStringArrayPtrOrDefault retrieves a string array property as a pointer or returns a default value.
-func GetStringArrayPtrOrDefault(props map[string]interface{}, prop string, defaultValue *[]string) *[]string {
-	val, err := GetStringArrayPtr(props, prop)
+func GetStringArrayPtrOrDefault(props map[string]interface{}, prop string, defaultValue *[]string, opts ...GetOptions) *[]string {
+	val, err := GetStringArrayPtr(props, prop, opts...)
 	if err != nil {
 		return defaultValue
 	}
@@ -77,7 +95,7 @@ func GetStringArrayPtrOrDefault(props map[string]interface{}, prop string, defau
 }
 
 // GetStringPointerArray retrieves a property as a slice of string pointers.
-func GetStringPointerArray(props map[string]interface{}, prop string) ([]*string, error) {
+func GetStringPointerArray(props map[string]interface{}, prop string, opts ...GetOptions) ([]*string, error) {
 	if props == nil {
 		return nil, &MissingFieldError{Prop: prop}
 	}
@@ -86,16 +104,24 @@ func GetStringPointerArray(props map[string]interface{}, prop string) ([]*string
 		return nil, &MissingFieldError{Prop: prop}
 	}
 	if arr, ok := val.([]*string); ok {
-		return arr, nil
+		return copyPointers(arr, opts), nil
 	}
-	if arr, ok := val.([]interface{}); ok {
+	// Also handle if the value is already []string
+	if arr, ok := val.([]string); ok {
+		res := make([]*string, len(arr))
+		for i := range arr {
+			res[i] = &arr[i]
+		}
+		return copyPointers(res, opts), nil
+	}
+	if arr, ok := toInterfaceSlice(val); ok {
 		res := make([]*string, len(arr))
 		for i, v := range arr {
 			if v == nil {
 				res[i] = nil
 				continue
 			}
-			if s, ok := v.(string); ok {
+			if s, err := convertToString(v); err == nil {
 				res[i] = &s
 			} else if sp, ok := v.(*string); ok {
 				res[i] = sp
@@ -103,22 +129,14 @@ func GetStringPointerArray(props map[string]interface{}, prop string) ([]*string
 				return nil, &InvalidTypeError{Prop: prop, Expected: "string pointer element", Actual: v}
 			}
 		}
-		return res, nil
-	}
-	// Also handle if the value is already []string
-	if arr, ok := val.([]string); ok {
-		res := make([]*string, len(arr))
-		for i := range arr {
-			res[i] = &arr[i]
-		}
-		return res, nil
+		return copyPointers(res, opts), nil
 	}
 	return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
 }
 
 // GetStringPointerArrayPtr retrieves a property as a pointer to a slice of string pointers.
-func GetStringPointerArrayPtr(props map[string]interface{}, prop string) (*[]*string, error) {
-	val, err := GetStringPointerArray(props, prop)
+func GetStringPointerArrayPtr(props map[string]interface{}, prop string, opts ...GetOptions) (*[]*string, error) {
+	val, err := GetStringPointerArray(props, prop, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -126,7 +144,7 @@ func GetStringPointerArrayPtr(props map[string]interface{}, prop string) (*[]*st
 }
 
 // GetObjectArray retrieves an object array property.
-func GetObjectArray[T any](props map[string]interface{}, prop string) ([]T, error) {
+func GetObjectArray[T any](props map[string]interface{}, prop string, opts ...GetOptions) ([]T, error) {
 	if props == nil {
 		return nil, &MissingFieldError{Prop: prop}
 	}
@@ -135,9 +153,9 @@ func GetObjectArray[T any](props map[string]interface{}, prop string) ([]T, erro
 		return nil, &MissingFieldError{Prop: prop}
 	}
 	if arr, ok := val.([]T); ok {
-		return arr, nil
+		return copyResult(arr, opts), nil
 	}
-	if arr, ok := val.([]interface{}); ok {
+	if arr, ok := toInterfaceSlice(val); ok {
 		res := make([]T, len(arr))
 		for i, v := range arr {
 			if castVal, ok := v.(T); ok {
@@ -151,17 +169,16 @@ func GetObjectArray[T any](props map[string]interface{}, prop string) ([]T, erro
 					continue
 				}
 			}
-			var zero T
-			return nil, &InvalidTypeError{Prop: prop, Expected: fmt.Sprintf("%T element", zero), Actual: v}
+			return nil, &InvalidTypeError{Prop: prop, Expected: typeName[T]() + " element", Actual: v}
 		}
-		return res, nil
+		return copyResult(res, opts), nil
 	}
 	return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
 }
 
 // MustGetObjectArray retrieves an object array property or panics.
-func MustGetObjectArray[T any](props map[string]interface{}, prop string) []T {
-	val, err := GetObjectArray[T](props, prop)
+func MustGetObjectArray[T any](props map[string]interface{}, prop string, opts ...GetOptions) []T {
+	val, err := GetObjectArray[T](props, prop, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -169,8 +186,8 @@ func MustGetObjectArray[T any](props map[string]interface{}, prop string) []T {
 }
 
 // GetObjectArrayOrDefault retrieves an object array property or returns a default value.
-func GetObjectArrayOrDefault[T any](props map[string]interface{}, prop string, defaultValue []T) []T {
-	val, err := GetObjectArray[T](props, prop)
+func GetObjectArrayOrDefault[T any](props map[string]interface{}, prop string, defaultValue []T, opts ...GetOptions) []T {
+	val, err := GetObjectArray[T](props, prop, opts...)
 	if err != nil {
 		return defaultValue
 	}
@@ -178,8 +195,8 @@ func GetObjectArrayOrDefault[T any](props map[string]interface{}, prop string, d
 }
 
 // GetObjectArrayPtr retrieves an object array property as a pointer.
-func GetObjectArrayPtr[T any](props map[string]interface{}, prop string) (*[]T, error) {
-	val, err := GetObjectArray[T](props, prop)
+func GetObjectArrayPtr[T any](props map[string]interface{}, prop string, opts ...GetOptions) (*[]T, error) {
+	val, err := GetObjectArray[T](props, prop, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -187,8 +204,8 @@ func GetObjectArrayPtr[T any](props map[string]interface{}, prop string) (*[]T,
 }
 
 // MustGetObjectArrayPtr retrieves an object array property as a pointer or panics.
-func MustGetObjectArrayPtr[T any](props map[string]interface{}, prop string) *[]T {
-	val, err := GetObjectArrayPtr[T](props, prop)
+func MustGetObjectArrayPtr[T any](props map[string]interface{}, prop string, opts ...GetOptions) *[]T {
+	val, err := GetObjectArrayPtr[T](props, prop, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -196,8 +213,8 @@ func MustGetObjectArrayPtr[T any](props map[string]interface{}, prop string) *[]
 }
 
 // GetObjectArrayPtrOrDefault retrieves an object array property as a pointer or returns a default value.
-func GetObjectArrayPtrOrDefault[T any](props map[string]interface{}, prop string, defaultValue *[]T) *[]T {
-	val, err := GetObjectArrayPtr[T](props, prop)
+func GetObjectArrayPtrOrDefault[T any](props map[string]interface{}, prop string, defaultValue *[]T, opts ...GetOptions) *[]T {
+	val, err := GetObjectArrayPtr[T](props, prop, opts...)
 	if err != nil {
 		return defaultValue
 	}
@@ -205,7 +222,7 @@ func GetObjectArrayPtrOrDefault[T any](props map[string]interface{}, prop string
 }
 
 // GetObjectPointerArray retrieves a property as a slice of object pointers.
-func GetObjectPointerArray[T any](props map[string]interface{}, prop string) ([]*T, error) {
+func GetObjectPointerArray[T any](props map[string]interface{}, prop string, opts ...GetOptions) ([]*T, error) {
 	if props == nil {
 		return nil, &MissingFieldError{Prop: prop}
 	}
@@ -214,9 +231,17 @@ func GetObjectPointerArray[T any](props map[string]interface{}, prop string) ([]
 		return nil, &MissingFieldError{Prop: prop}
 	}
 	if arr, ok := val.([]*T); ok {
-		return arr, nil
+		return copyPointers(arr, opts), nil
 	}
-	if arr, ok := val.([]interface{}); ok {
+	// Also handle if the value is already []T
+	if arr, ok := val.([]T); ok {
+		res := make([]*T, len(arr))
+		for i := range arr {
+			res[i] = &arr[i]
+		}
+		return copyPointers(res, opts), nil
+	}
+	if arr, ok := toInterfaceSlice(val); ok {
 		res := make([]*T, len(arr))
 		for i, v := range arr {
 			if v == nil {
@@ -238,25 +263,16 @@ func GetObjectPointerArray[T any](props map[string]interface{}, prop string) ([]
 					continue
 				}
 			}
-			var zero T
-			return nil, &InvalidTypeError{Prop: prop, Expected: fmt.Sprintf("*%T element", zero), Actual: v}
+			return nil, &InvalidTypeError{Prop: prop, Expected: "*" + typeName[T]() + " element", Actual: v}
 		}
-		return res, nil
-	}
-	// Also handle if the value is already []T
-	if arr, ok := val.([]T); ok {
-		res := make([]*T, len(arr))
-		for i := range arr {
-			res[i] = &arr[i]
-		}
-		return res, nil
+		return copyPointers(res, opts), nil
 	}
 	return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
 }
 
 // GetObjectPointerArrayPtr retrieves a property as a pointer to a slice of object pointers.
-func GetObjectPointerArrayPtr[T any](props map[string]interface{}, prop string) (*[]*T, error) {
-	val, err := GetObjectPointerArray[T](props, prop)
+func GetObjectPointerArrayPtr[T any](props map[string]interface{}, prop string, opts ...GetOptions) (*[]*T, error) {
+	val, err := GetObjectPointerArray[T](props, prop, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -264,7 +280,7 @@ func GetObjectPointerArrayPtr[T any](props map[string]interface{}, prop string)
 }
 
 // GetDateArray retrieves a date array property.
-func GetDateArray(props map[string]interface{}, prop string) ([]time.Time, error) {
+func GetDateArray(props map[string]interface{}, prop string, opts ...GetOptions) ([]time.Time, error) {
 	if props == nil {
 		return nil, &MissingFieldError{Prop: prop}
 	}
@@ -273,9 +289,9 @@ func GetDateArray(props map[string]interface{}, prop string) ([]time.Time, error
 		return nil, &MissingFieldError{Prop: prop}
 	}
 	if arr, ok := val.([]time.Time); ok {
-		return arr, nil
+		return copyResult(arr, opts), nil
 	}
-	if arr, ok := val.([]interface{}); ok {
+	if arr, ok := toInterfaceSlice(val); ok {
 		res := make([]time.Time, len(arr))
 		for i, v := range arr {
 			if t, err := parseDate(v); err == nil {
@@ -290,8 +306,8 @@ func GetDateArray(props map[string]interface{}, prop string) ([]time.Time, error
 }
 
 // MustGetDateArray retrieves a date array property or panics.
-func MustGetDateArray(props map[string]interface{}, prop string) []time.Time {
-	val, err := GetDateArray(props, prop)
+func MustGetDateArray(props map[string]interface{}, prop string, opts ...GetOptions) []time.Time {
+	val, err := GetDateArray(props, prop, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -299,8 +315,8 @@ func MustGetDateArray(props map[string]interface{}, prop string) []time.Time {
 }
 
 // GetDateArrayOrDefault retrieves a date array property or returns a default value.
-func GetDateArrayOrDefault(props map[string]interface{}, prop string, defaultValue []time.Time) []time.Time {
-	val, err := GetDateArray(props, prop)
+func GetDateArrayOrDefault(props map[string]interface{}, prop string, defaultValue []time.Time, opts ...GetOptions) []time.Time {
+	val, err := GetDateArray(props, prop, opts...)
 	if err != nil {
 		return defaultValue
 	}
@@ -308,8 +324,8 @@ func GetDateArrayOrDefault(props map[string]interface{}, prop string, defaultVal
 }
 
 // GetDateArrayPtr retrieves a date array property as a pointer.
-func GetDateArrayPtr(props map[string]interface{}, prop string) (*[]time.Time, error) {
-	val, err := GetDateArray(props, prop)
+func GetDateArrayPtr(props map[string]interface{}, prop string, opts ...GetOptions) (*[]time.Time, error) {
+	val, err := GetDateArray(props, prop, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -317,8 +333,8 @@ func GetDateArrayPtr(props map[string]interface{}, prop string) (*[]time.Time, e
 }
 
 // MustGetDateArrayPtr retrieves a date array property as a pointer or panics.
-func MustGetDateArrayPtr(props map[string]interface{}, prop string) *[]time.Time {
-	val, err := GetDateArrayPtr(props, prop)
+func MustGetDateArrayPtr(props map[string]interface{}, prop string, opts ...GetOptions) *[]time.Time {
+	val, err := GetDateArrayPtr(props, prop, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -326,8 +342,8 @@ func MustGetDateArrayPtr(props map[string]interface{}, prop string) *[]time.Time
 }
 
 // GetDateArrayPtrOrDefault retrieves a date array property as a pointer or returns a default value.
-func GetDateArrayPtrOrDefault(props map[string]interface{}, prop string, defaultValue *[]time.Time) *[]time.Time {
-	val, err := GetDateArrayPtr(props, prop)
+func GetDateArrayPtrOrDefault(props map[string]interface{}, prop string, defaultValue *[]time.Time, opts ...GetOptions) *[]time.Time {
+	val, err := GetDateArrayPtr(props, prop, opts...)
 	if err != nil {
 		return defaultValue
 	}
@@ -335,7 +351,7 @@ func GetDateArrayPtrOrDefault(props map[string]interface{}, prop string, default
 }
 
 // GetDatePointerArray retrieves a property as a slice of date pointers.
-func GetDatePointerArray(props map[string]interface{}, prop string) ([]*time.Time, error) {
+func GetDatePointerArray(props map[string]interface{}, prop string, opts ...GetOptions) ([]*time.Time, error) {
 	if props == nil {
 		return nil, &MissingFieldError{Prop: prop}
 	}
@@ -344,37 +360,39 @@ func GetDatePointerArray(props map[string]interface{}, prop string) ([]*time.Tim
 		return nil, &MissingFieldError{Prop: prop}
 	}
 	if arr, ok := val.([]*time.Time); ok {
-		return arr, nil
+		return copyPointers(arr, opts), nil
 	}
-	if arr, ok := val.([]interface{}); ok {
+	// Also handle if the value is already []time.Time
+	if arr, ok := val.([]time.Time); ok {
+		res := make([]*time.Time, len(arr))
+		for i := range arr {
+			res[i] = &arr[i]
+		}
+		return copyPointers(res, opts), nil
+	}
+	if arr, ok := toInterfaceSlice(val); ok {
 		res := make([]*time.Time, len(arr))
 		for i, v := range arr {
 			if v == nil {
 				res[i] = nil
 				continue
 			}
-			if t, err := parseDate(v); err == nil {
+			if tp, ok := v.(*time.Time); ok {
+				res[i] = tp
+			} else if t, err := parseDate(v); err == nil {
 				res[i] = &t
 			} else {
 				return nil, &InvalidTypeError{Prop: prop, Expected: "date pointer element", Actual: v, Cause: err}
 			}
 		}
-		return res, nil
-	}
-	// Also handle if the value is already []time.Time
-	if arr, ok := val.([]time.Time); ok {
-		res := make([]*time.Time, len(arr))
-		for i := range arr {
-			res[i] = &arr[i]
-		}
-		return res, nil
+		return copyPointers(res, opts), nil
 	}
 	return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
 }
 
 // GetDatePointerArrayPtr retrieves a property as a pointer to a slice of date pointers.
-func GetDatePointerArrayPtr(props map[string]interface{}, prop string) (*[]*time.Time, error) {
-	val, err := GetDatePointerArray(props, prop)
+func GetDatePointerArrayPtr(props map[string]interface{}, prop string, opts ...GetOptions) (*[]*time.Time, error) {
+	val, err := GetDatePointerArray(props, prop, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -382,7 +400,7 @@ func GetDatePointerArrayPtr(props map[string]interface{}, prop string) (*[]*time
 }
 
 // GetNumberArray retrieves a number array property.
-func GetNumberArray[T NumberConstraint](props map[string]interface{}, prop string) ([]T, error) {
+func GetNumberArray[T NumberConstraint](props map[string]interface{}, prop string, opts ...GetOptions) ([]T, error) {
 	if props == nil {
 		return nil, &MissingFieldError{Prop: prop}
 	}
@@ -390,28 +408,27 @@ func GetNumberArray[T NumberConstraint](props map[string]interface{}, prop strin
 	if !ok {
 		return nil, &MissingFieldError{Prop: prop}
 	}
-	if arr, ok := val.([]interface{}); ok {
+	// Also handle if the value is already []T (though unlikely from JSON unmarshal into map[string]interface{})
+	if arr, ok := val.([]T); ok {
+		return copyResult(arr, opts), nil
+	}
+	if arr, ok := toInterfaceSlice(val); ok {
 		res := make([]T, len(arr))
 		for i, v := range arr {
 			if num, err := convertToNumber[T](v); err == nil {
 				res[i] = num
 			} else {
-				var zero T
-				return nil, &InvalidTypeError{Prop: prop, Expected: fmt.Sprintf("%T element", zero), Actual: v, Cause: err}
+				return nil, &InvalidTypeError{Prop: prop, Expected: typeName[T]() + " element", Actual: v, Cause: err}
 			}
 		}
 		return res, nil
 	}
-	// Also handle if the value is already []T (though unlikely from JSON unmarshal into map[string]interface{})
-	if arr, ok := val.([]T); ok {
-		return arr, nil
-	}
 	return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
 }
 
 // MustGetNumberArray retrieves a number array property or panics.
-func MustGetNumberArray[T NumberConstraint](props map[string]interface{}, prop string) []T {
-	val, err := GetNumberArray[T](props, prop)
+func MustGetNumberArray[T NumberConstraint](props map[string]interface{}, prop string, opts ...GetOptions) []T {
+	val, err := GetNumberArray[T](props, prop, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -419,8 +436,8 @@ func MustGetNumberArray[T NumberConstraint](props map[string]interface{}, prop s
 }
 
 // GetNumberArrayOrDefault retrieves a number array property or returns a default value.
-func GetNumberArrayOrDefault[T NumberConstraint](props map[string]interface{}, prop string, defaultValue []T) []T {
-	val, err := GetNumberArray[T](props, prop)
+func GetNumberArrayOrDefault[T NumberConstraint](props map[string]interface{}, prop string, defaultValue []T, opts ...GetOptions) []T {
+	val, err := GetNumberArray[T](props, prop, opts...)
 	if err != nil {
 		return defaultValue
 	}
@@ -428,8 +445,8 @@ func GetNumberArrayOrDefault[T NumberConstraint](props map[string]interface{}, p
 }
 
 // GetNumberArrayPtr retrieves a number array property as a pointer.
-func GetNumberArrayPtr[T NumberConstraint](props map[string]interface{}, prop string) (*[]T, error) {
-	val, err := GetNumberArray[T](props, prop)
+func GetNumberArrayPtr[T NumberConstraint](props map[string]interface{}, prop string, opts ...GetOptions) (*[]T, error) {
+	val, err := GetNumberArray[T](props, prop, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -437,8 +454,8 @@ func GetNumberArrayPtr[T NumberConstraint](props map[string]interface{}, prop st
 }
 
 // MustGetNumberArrayPtr retrieves a number array property as a pointer or panics.
-func MustGetNumberArrayPtr[T NumberConstraint](props map[string]interface{}, prop string) *[]T {
-	val, err := GetNumberArrayPtr[T](props, prop)
+func MustGetNumberArrayPtr[T NumberConstraint](props map[string]interface{}, prop string, opts ...GetOptions) *[]T {
+	val, err := GetNumberArrayPtr[T](props, prop, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -446,8 +463,8 @@ func MustGetNumberArrayPtr[T NumberConstraint](props map[string]interface{}, pro
 }
 
 // GetNumberArrayPtrOrDefault retrieves a number array property as a pointer or returns a default value.
-func GetNumberArrayPtrOrDefault[T NumberConstraint](props map[string]interface{}, prop string, defaultValue *[]T) *[]T {
-	val, err := GetNumberArrayPtr[T](props, prop)
+func GetNumberArrayPtrOrDefault[T NumberConstraint](props map[string]interface{}, prop string, defaultValue *[]T, opts ...GetOptions) *[]T {
+	val, err := GetNumberArrayPtr[T](props, prop, opts...)
 	if err != nil {
 		return defaultValue
 	}
@@ -455,7 +472,7 @@ func GetNumberArrayPtrOrDefault[T NumberConstraint](props map[string]interface{}
 }
 
 // GetNumberPointerArray retrieves a property as a slice of number pointers.
-func GetNumberPointerArray[T NumberConstraint](props map[string]interface{}, prop string) ([]*T, error) {
+func GetNumberPointerArray[T NumberConstraint](props map[string]interface{}, prop string, opts ...GetOptions) ([]*T, error) {
 	if props == nil {
 		return nil, &MissingFieldError{Prop: prop}
 	}
@@ -464,38 +481,39 @@ func GetNumberPointerArray[T NumberConstraint](props map[string]interface{}, pro
 		return nil, &MissingFieldError{Prop: prop}
 	}
 	if arr, ok := val.([]*T); ok {
-		return arr, nil
+		return copyPointers(arr, opts), nil
 	}
-	if arr, ok := val.([]interface{}); ok {
+	// Also handle if the value is already []T
+	if arr, ok := val.([]T); ok {
+		res := make([]*T, len(arr))
+		for i := range arr {
+			res[i] = &arr[i]
+		}
+		return copyPointers(res, opts), nil
+	}
+	if arr, ok := toInterfaceSlice(val); ok {
 		res := make([]*T, len(arr))
 		for i, v := range arr {
 			if v == nil {
 				res[i] = nil
 				continue
 			}
-			if num, err := convertToNumber[T](v); err == nil {
+			if np, ok := v.(*T); ok {
+				res[i] = np
+			} else if num, err := convertToNumber[T](v); err == nil {
 				res[i] = &num
 			} else {
-				var zero T
-				return nil, &InvalidTypeError{Prop: prop, Expected: fmt.Sprintf("*%T element", zero), Actual: v, Cause: err}
+				return nil, &InvalidTypeError{Prop: prop, Expected: "*" + typeName[T]() + " element", Actual: v, Cause: err}
 			}
 		}
-		return res, nil
-	}
-	// Also handle if the value is already []T
-	if arr, ok := val.([]T); ok {
-		res := make([]*T, len(arr))
-		for i := range arr {
-			res[i] = &arr[i]
-		}
-		return res, nil
+		return copyPointers(res, opts), nil
 	}
 	return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
 }
 
 // GetNumberPointerArrayPtr retrieves a property as a pointer to a slice of number pointers.
-func GetNumberPointerArrayPtr[T NumberConstraint](props map[string]interface{}, prop string) (*[]*T, error) {
-	val, err := GetNumberPointerArray[T](props, prop)
+func GetNumberPointerArrayPtr[T NumberConstraint](props map[string]interface{}, prop string, opts ...GetOptions) (*[]*T, error) {
+	val, err := GetNumberPointerArray[T](props, prop, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -503,7 +521,7 @@ func GetNumberPointerArrayPtr[T NumberConstraint](props map[string]interface{},
 }
 
 // GetBooleanArray retrieves a boolean array property.
-func GetBooleanArray(props map[string]interface{}, prop string) ([]bool, error) {
+func GetBooleanArray(props map[string]interface{}, prop string, opts ...GetOptions) ([]bool, error) {
 	if props == nil {
 		return nil, &MissingFieldError{Prop: prop}
 	}
@@ -511,10 +529,13 @@ func GetBooleanArray(props map[string]interface{}, prop string) ([]bool, error)
 	if !ok {
 		return nil, &MissingFieldError{Prop: prop}
 	}
-	if arr, ok := val.([]interface{}); ok {
+	if arr, ok := val.([]bool); ok {
+		return copyResult(arr, opts), nil
+	}
+	if arr, ok := toInterfaceSlice(val); ok {
 		res := make([]bool, len(arr))
 		for i, v := range arr {
-			if b, ok := v.(bool); ok {
+			if b, err := convertToBool(v); err == nil {
 				res[i] = b
 			} else {
 				return nil, &InvalidTypeError{Prop: prop, Expected: "bool element", Actual: v}
@@ -522,15 +543,12 @@ func GetBooleanArray(props map[string]interface{}, prop string) ([]bool, error)
 		}
 		return res, nil
 	}
-	if arr, ok := val.([]bool); ok {
-		return arr, nil
-	}
 	return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
 }
 
 // MustGetBooleanArray retrieves a boolean array property or panics.
-func MustGetBooleanArray(props map[string]interface{}, prop string) []bool {
-	val, err := GetBooleanArray(props, prop)
+func MustGetBooleanArray(props map[string]interface{}, prop string, opts ...GetOptions) []bool {
+	val, err := GetBooleanArray(props, prop, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -538,8 +556,8 @@ func MustGetBooleanArray(props map[string]interface{}, prop string) []bool {
 }
 
 // GetBooleanArrayOrDefault retrieves a boolean array property or returns a default value.
-func GetBooleanArrayOrDefault(props map[string]interface{}, prop string, defaultValue []bool) []bool {
-	val, err := GetBooleanArray(props, prop)
+func GetBooleanArrayOrDefault(props map[string]interface{}, prop string, defaultValue []bool, opts ...GetOptions) []bool {
+	val, err := GetBooleanArray(props, prop, opts...)
 	if err != nil {
 		return defaultValue
 	}
@@ -547,8 +565,8 @@ func GetBooleanArrayOrDefault(props map[string]interface{}, prop string, default
 }
 
 // GetBooleanArrayPtr retrieves a boolean array property as a pointer.
-func GetBooleanArrayPtr(props map[string]interface{}, prop string) (*[]bool, error) {
-	val, err := GetBooleanArray(props, prop)
+func GetBooleanArrayPtr(props map[string]interface{}, prop string, opts ...GetOptions) (*[]bool, error) {
+	val, err := GetBooleanArray(props, prop, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -556,8 +574,8 @@ func GetBooleanArrayPtr(props map[string]interface{}, prop string) (*[]bool, err
 }
 
 // MustGetBooleanArrayPtr retrieves a boolean array property as a pointer or panics.
-func MustGetBooleanArrayPtr(props map[string]interface{}, prop string) *[]bool {
-	val, err := GetBooleanArrayPtr(props, prop)
+func MustGetBooleanArrayPtr(props map[string]interface{}, prop string, opts ...GetOptions) *[]bool {
+	val, err := GetBooleanArrayPtr(props, prop, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -565,8 +583,8 @@ func MustGetBooleanArrayPtr(props map[string]interface{}, prop string) *[]bool {
 }
 
 // GetBooleanArrayPtrOrDefault retrieves a boolean array property as a pointer or returns a default value.
-func GetBooleanArrayPtrOrDefault(props map[string]interface{}, prop string, defaultValue *[]bool) *[]bool {
-	val, err := GetBooleanArrayPtr(props, prop)
+func GetBooleanArrayPtrOrDefault(props map[string]interface{}, prop string, defaultValue *[]bool, opts ...GetOptions) *[]bool {
+	val, err := GetBooleanArrayPtr(props, prop, opts...)
 	if err != nil {
 		return defaultValue
 	}
@@ -574,7 +592,7 @@ func GetBooleanArrayPtrOrDefault(props map[string]interface{}, prop string, defa
 }
 
 // GetBooleanPointerArray retrieves a property as a slice of boolean pointers.
-func GetBooleanPointerArray(props map[string]interface{}, prop string) ([]*bool, error) {
+func GetBooleanPointerArray(props map[string]interface{}, prop string, opts ...GetOptions) ([]*bool, error) {
 	if props == nil {
 		return nil, &MissingFieldError{Prop: prop}
 	}
@@ -583,16 +601,24 @@ func GetBooleanPointerArray(props map[string]interface{}, prop string) ([]*bool,
 		return nil, &MissingFieldError{Prop: prop}
 	}
 	if arr, ok := val.([]*bool); ok {
-		return arr, nil
+		return copyPointers(arr, opts), nil
 	}
-	if arr, ok := val.([]interface{}); ok {
+	// Also handle if the value is already []bool
+	if arr, ok := val.([]bool); ok {
+		res := make([]*bool, len(arr))
+		for i := range arr {
+			res[i] = &arr[i]
+		}
+		return copyPointers(res, opts), nil
+	}
+	if arr, ok := toInterfaceSlice(val); ok {
 		res := make([]*bool, len(arr))
 		for i, v := range arr {
 			if v == nil {
 				res[i] = nil
 				continue
 			}
-			if b, ok := v.(bool); ok {
+			if b, err := convertToBool(v); err == nil {
 				res[i] = &b
 			} else if bp, ok := v.(*bool); ok {
 				res[i] = bp
@@ -600,33 +626,99 @@ func GetBooleanPointerArray(props map[string]interface{}, prop string) ([]*bool,
 				return nil, &InvalidTypeError{Prop: prop, Expected: "bool pointer element", Actual: v}
 			}
 		}
-		return res, nil
-	}
-	// Also handle if the value is already []bool
-	if arr, ok := val.([]bool); ok {
-		res := make([]*bool, len(arr))
-		for i := range arr {
-			res[i] = &arr[i]
-		}
-		return res, nil
+		return copyPointers(res, opts), nil
 	}
 	return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
 }
 
 // GetBooleanPointerArrayPtr retrieves a property as a pointer to a slice of boolean pointers.
-func GetBooleanPointerArrayPtr(props map[string]interface{}, prop string) (*[]*bool, error) {
-	val, err := GetBooleanPointerArray(props, prop)
+func GetBooleanPointerArrayPtr(props map[string]interface{}, prop string, opts ...GetOptions) (*[]*bool, error) {
+	val, err := GetBooleanPointerArray(props, prop, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetObjectArrayWith retrieves an array of objects, building each element with constructorFunc.
+// Errors returned by constructorFunc are wrapped in a *ConstructorError carrying the element index.
+func GetObjectArrayWith[T any](props map[string]interface{}, prop string, constructorFunc func(map[string]interface{}) (T, error)) ([]T, error) {
+	if props == nil {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	arr, ok := toInterfaceSlice(val)
+	if !ok {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
+	}
+	res := make([]T, len(arr))
+	for i, v := range arr {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, &ElementError{Prop: prop, Indices: []int{i}, Expected: "object", Actual: v}
+		}
+		obj, err := constructorFunc(m)
+		if err != nil {
+			return nil, &ConstructorError{Prop: prop, Index: i, Cause: err}
+		}
+		res[i] = obj
+	}
+	return res, nil
+}
+
+// MustGetObjectArrayWith retrieves an array of objects built by constructorFunc or panics.
+func MustGetObjectArrayWith[T any](props map[string]interface{}, prop string, constructorFunc func(map[string]interface{}) (T, error)) []T {
+	val, err := GetObjectArrayWith(props, prop, constructorFunc)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetObjectArrayWithOrDefault retrieves an array of objects built by constructorFunc or returns a default value.
+func GetObjectArrayWithOrDefault[T any](props map[string]interface{}, prop string, constructorFunc func(map[string]interface{}) (T, error), defaultValue []T) []T {
+	val, err := GetObjectArrayWith(props, prop, constructorFunc)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetObjectArrayWithPtr retrieves an array of objects built by constructorFunc as a pointer.
+func GetObjectArrayWithPtr[T any](props map[string]interface{}, prop string, constructorFunc func(map[string]interface{}) (T, error)) (*[]T, error) {
+	val, err := GetObjectArrayWith(props, prop, constructorFunc)
 	if err != nil {
 		return nil, err
 	}
 	return &val, nil
 }
 
+// MustGetObjectArrayWithPtr retrieves an array of objects built by constructorFunc as a pointer or panics.
+func MustGetObjectArrayWithPtr[T any](props map[string]interface{}, prop string, constructorFunc func(map[string]interface{}) (T, error)) *[]T {
+	val, err := GetObjectArrayWithPtr(props, prop, constructorFunc)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetObjectArrayWithPtrOrDefault retrieves an array of objects built by constructorFunc as a pointer or returns a default value.
+func GetObjectArrayWithPtrOrDefault[T any](props map[string]interface{}, prop string, constructorFunc func(map[string]interface{}) (T, error), defaultValue *[]T) *[]T {
+	val, err := GetObjectArrayWithPtr(props, prop, constructorFunc)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
 // Legacy Aliases
 
 // GetStringArrayPropOrDefault
-func GetStringArrayPropOrDefault(props map[string]interface{}, prop string, defaultValue []string) []string {
-	return GetStringArrayOrDefault(props, prop, defaultValue)
+func GetStringArrayPropOrDefault(props map[string]interface{}, prop string, defaultValue []string, opts ...GetOptions) []string {
+	return GetStringArrayOrDefault(props, prop, defaultValue, opts...)
 }
 
 // GetStringArrayPropOrThrow
@@ -643,8 +735,8 @@ func GetStringArrayPropOrThrow(props map[string]interface{}, prop string, messag
 }
 
 // GetObjectArrayPropOrDefault
-func GetObjectArrayPropOrDefault[T any](props map[string]interface{}, prop string, defaultValue []T) []T {
-	return GetObjectArrayOrDefault[T](props, prop, defaultValue)
+func GetObjectArrayPropOrDefault[T any](props map[string]interface{}, prop string, defaultValue []T, opts ...GetOptions) []T {
+	return GetObjectArrayOrDefault[T](props, prop, defaultValue, opts...)
 }
 
 // GetObjectArrayFunctionPropOrDefault
@@ -656,7 +748,7 @@ func GetObjectArrayFunctionPropOrDefault[T any](props map[string]interface{}, pr
 	if !ok {
 		return defaultValue
 	}
-	if arr, ok := val.([]interface{}); ok {
+	if arr, ok := toInterfaceSlice(val); ok {
 		res := make([]T, len(arr))
 		for i, v := range arr {
 			if m, ok := v.(map[string]interface{}); ok {
@@ -671,6 +763,6 @@ func GetObjectArrayFunctionPropOrDefault[T any](props map[string]interface{}, pr
 }
 
 // GetDateArrayPropOrDefault
-func GetDateArrayPropOrDefault(props map[string]interface{}, prop string, defaultValue []time.Time) []time.Time {
-	return GetDateArrayOrDefault(props, prop, defaultValue)
+func GetDateArrayPropOrDefault(props map[string]interface{}, prop string, defaultValue []time.Time, opts ...GetOptions) []time.Time {
+	return GetDateArrayOrDefault(props, prop, defaultValue, opts...)
 }
@@ -1,6 +1,7 @@
 package go_objectutils
 
 import (
+	"fmt"
 	"math/big"
 )
 
@@ -15,6 +16,14 @@ func GetBigInt(props map[string]interface{}, prop string) (*big.Int, error) {
 		return nil, &MissingFieldError{Prop: prop}
 	}
 
+	if bi, err := convertToBigInt(val); err == nil {
+		return bi, nil
+	}
+	return nil, &InvalidTypeError{Prop: prop, Expected: "big.Int convertible", Actual: val}
+}
+
+// convertToBigInt converts strings, int, int64 and float64 values to a big.Int.
+func convertToBigInt(val interface{}) (*big.Int, error) {
 	switch v := val.(type) {
 	case string:
 		bi := new(big.Int)
@@ -33,8 +42,7 @@ func GetBigInt(props map[string]interface{}, prop string) (*big.Int, error) {
 		i, _ := bf.Int(nil)
 		return i, nil
 	}
-
-	return nil, &InvalidTypeError{Prop: prop, Expected: "big.Int convertible", Actual: val}
+	return nil, fmt.Errorf("cannot convert %T to big.Int", val)
 }
 
 // MustGetBigInt retrieves a big.Int property or panics.
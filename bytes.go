@@ -0,0 +1,223 @@
+package go_objectutils
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// BytesEncoding selects how string values are decoded by the bytes accessors.
+type BytesEncoding int
+
+const (
+	// EncodingBase64 is standard padded base64 (RFC 4648 section 4). It is the default.
+	EncodingBase64 BytesEncoding = iota
+	// EncodingBase64URL is URL-safe padded base64 (RFC 4648 section 5).
+	EncodingBase64URL
+	// EncodingBase64Raw is standard base64 without padding.
+	EncodingBase64Raw
+	// EncodingBase64RawURL is URL-safe base64 without padding.
+	EncodingBase64RawURL
+	// EncodingHex is hexadecimal.
+	EncodingHex
+	// EncodingAuto tries padded standard and URL-safe base64, then hex, then unpadded base64.
+	// Detection is inherently ambiguous: a string such as "deadbeef" is both valid hex and valid
+	// base64 and is decoded as base64. Select an explicit encoding when the format is known.
+	EncodingAuto
+)
+
+// BytesOptions configures the bytes accessors.
+type BytesOptions struct {
+	// Encoding selects the string encoding. The zero value is EncodingBase64.
+	Encoding BytesEncoding
+	// MaxLength, when greater than zero, rejects payloads that decode to more than this many bytes.
+	MaxLength int
+}
+
+func (o BytesOptions) String() string {
+	switch o.Encoding {
+	case EncodingBase64:
+		return "base64"
+	case EncodingBase64URL:
+		return "base64url"
+	case EncodingBase64Raw:
+		return "raw base64"
+	case EncodingBase64RawURL:
+		return "raw base64url"
+	case EncodingHex:
+		return "hex"
+	}
+	return "base64 or hex"
+}
+
+// decodedLen returns an upper bound on the decoded length of s, used to reject oversized
+// payloads before decoding them. For EncodingAuto it is the bound of hex, the encoding with
+// the shortest output, so only payloads too large under every encoding are rejected.
+func (o BytesOptions) decodedLen(s string) int {
+	switch o.Encoding {
+	case EncodingHex, EncodingAuto:
+		return hex.DecodedLen(len(s))
+	case EncodingBase64Raw, EncodingBase64RawURL:
+		return base64.RawStdEncoding.DecodedLen(len(s))
+	}
+	return base64.StdEncoding.DecodedLen(len(s))
+}
+
+func (o BytesOptions) decode(s string) ([]byte, error) {
+	switch o.Encoding {
+	case EncodingBase64:
+		return base64.StdEncoding.DecodeString(s)
+	case EncodingBase64URL:
+		return base64.URLEncoding.DecodeString(s)
+	case EncodingBase64Raw:
+		return base64.RawStdEncoding.DecodeString(s)
+	case EncodingBase64RawURL:
+		return base64.RawURLEncoding.DecodeString(s)
+	case EncodingHex:
+		return hex.DecodeString(s)
+	}
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding} {
+		if b, err := enc.DecodeString(s); err == nil {
+			return b, nil
+		}
+	}
+	// Unpadded base64 accepts almost any string of hex digits, so hex is tried before it.
+	if b, err := hex.DecodeString(s); err == nil {
+		return b, nil
+	}
+	for _, enc := range []*base64.Encoding{base64.RawStdEncoding, base64.RawURLEncoding} {
+		if b, err := enc.DecodeString(s); err == nil {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("value is neither hex nor base64")
+}
+
+// convertToBytes converts a []byte or encoded string to bytes, reporting errors against prop.
+func convertToBytes(prop string, val interface{}, opts []BytesOptions) ([]byte, error) {
+	var opt BytesOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	var res []byte
+	switch v := val.(type) {
+	case []byte:
+		res = v
+	case string:
+		// decodedLen over-estimates padded base64 by up to two bytes.
+		if opt.MaxLength > 0 && opt.decodedLen(v) > opt.MaxLength+2 {
+			return nil, &ValidationError{Prop: prop, Value: val, Reason: fmt.Sprintf("encoded payload exceeds %d bytes", opt.MaxLength)}
+		}
+		b, err := opt.decode(v)
+		if err != nil {
+			return nil, &InvalidTypeError{Prop: prop, Expected: opt.String() + " bytes", Actual: val, Cause: err}
+		}
+		res = b
+	default:
+		return nil, &InvalidTypeError{Prop: prop, Expected: opt.String() + " bytes", Actual: val}
+	}
+	if opt.MaxLength > 0 && len(res) > opt.MaxLength {
+		return nil, &ValidationError{Prop: prop, Value: val, Reason: fmt.Sprintf("length %d exceeds %d bytes", len(res), opt.MaxLength)}
+	}
+	return res, nil
+}
+
+// GetBytes retrieves a binary property from a []byte value or a base64 or hex string.
+// An optional BytesOptions selects the encoding and a maximum decoded length.
+func GetBytes(props map[string]interface{}, prop string, opts ...BytesOptions) ([]byte, error) {
+	if props == nil {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	return convertToBytes(prop, val, opts)
+}
+
+// MustGetBytes retrieves a binary property or panics.
+func MustGetBytes(props map[string]interface{}, prop string, opts ...BytesOptions) []byte {
+	val, err := GetBytes(props, prop, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetBytesOrDefault retrieves a binary property or returns a default value.
+func GetBytesOrDefault(props map[string]interface{}, prop string, defaultValue []byte, opts ...BytesOptions) []byte {
+	val, err := GetBytes(props, prop, opts...)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetBytesPtr retrieves a binary property as a pointer.
+func GetBytesPtr(props map[string]interface{}, prop string, opts ...BytesOptions) (*[]byte, error) {
+	val, err := GetBytes(props, prop, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// MustGetBytesPtr retrieves a binary property as a pointer or panics.
+func MustGetBytesPtr(props map[string]interface{}, prop string, opts ...BytesOptions) *[]byte {
+	val, err := GetBytesPtr(props, prop, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetBytesPtrOrDefault retrieves a binary property as a pointer or returns a default value.
+func GetBytesPtrOrDefault(props map[string]interface{}, prop string, defaultValue *[]byte, opts ...BytesOptions) *[]byte {
+	val, err := GetBytesPtr(props, prop, opts...)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetBytesArray retrieves an array of binary values.
+func GetBytesArray(props map[string]interface{}, prop string, opts ...BytesOptions) ([][]byte, error) {
+	if props == nil {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	arr, ok := toInterfaceSlice(val)
+	if !ok {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
+	}
+	res := make([][]byte, len(arr))
+	for i, v := range arr {
+		b, err := convertToBytes(fmt.Sprintf("%s[%d]", prop, i), v, opts)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = b
+	}
+	return res, nil
+}
+
+// MustGetBytesArray retrieves an array of binary values or panics.
+func MustGetBytesArray(props map[string]interface{}, prop string, opts ...BytesOptions) [][]byte {
+	val, err := GetBytesArray(props, prop, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetBytesArrayOrDefault retrieves an array of binary values or returns a default value.
+func GetBytesArrayOrDefault(props map[string]interface{}, prop string, defaultValue [][]byte, opts ...BytesOptions) [][]byte {
+	val, err := GetBytesArray(props, prop, opts...)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
@@ -0,0 +1,224 @@
+package go_objectutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"hash"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// CanonicalJSON encodes v using the JSON Canonicalization Scheme of RFC 8785: object members
+// sorted by their UTF-16 code units, no insignificant whitespace, minimal string escaping and
+// numbers formatted as ECMAScript does. Equal documents produce identical bytes regardless of key
+// order or the Go types of their numbers. Integers that an IEEE 754 double cannot hold exactly,
+// NaN, infinities and invalid UTF-8 are rejected rather than silently altered.
+// Values of other types, such as structs, are encoded through encoding/json first.
+func CanonicalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, nil, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Hash writes the canonical JSON form of doc to h and returns the digest, so documents that are
+// equal as JSON hash identically. h is reset first.
+func Hash(doc interface{}, h hash.Hash) ([]byte, error) {
+	b, err := CanonicalJSON(doc)
+	if err != nil {
+		return nil, err
+	}
+	h.Reset()
+	h.Write(b)
+	return h.Sum(nil), nil
+}
+
+func writeCanonical(buf *bytes.Buffer, path []string, v interface{}) error {
+	if n, ok := v.(json.Number); ok {
+		f, err := strconv.ParseFloat(string(n), 64)
+		if err != nil {
+			return &InvalidTypeError{Prop: formatPointer(path), Expected: "number", Actual: v, Cause: err}
+		}
+		return writeCanonicalNumber(buf, path, v, f)
+	}
+	rv := reflect.ValueOf(v)
+	kind := rv.Kind()
+	if _, ok := v.(json.Marshaler); ok {
+		kind = reflect.Struct
+	} else if kind == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+		// encoding/json writes byte slices as base64 strings.
+		kind = reflect.Struct
+	}
+	switch kind {
+	case reflect.Invalid:
+		buf.WriteString("null")
+		return nil
+	case reflect.Bool:
+		buf.WriteString(strconv.FormatBool(rv.Bool()))
+		return nil
+	case reflect.String:
+		return writeCanonicalString(buf, path, rv.String())
+	case reflect.Float32, reflect.Float64:
+		return writeCanonicalNumber(buf, path, v, rv.Float())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		r, _ := exactNumber(v)
+		f, exact := r.Float64()
+		if !exact {
+			return &ValidationError{Prop: formatPointer(path), Value: v, Reason: "integer cannot be represented exactly as an IEEE 754 double"}
+		}
+		return writeCanonicalNumber(buf, path, v, f)
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeCanonical(buf, path, rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		buf.WriteByte('[')
+		for i := 0; i < rv.Len(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, append(path[:len(path):len(path)], strconv.Itoa(i)), rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case reflect.Map:
+		if rv.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		if m, ok := v.(map[string]interface{}); ok {
+			return writeCanonicalObject(buf, path, m)
+		}
+		if rv.Type().Key().Kind() == reflect.String {
+			m := make(map[string]interface{}, rv.Len())
+			iter := rv.MapRange()
+			for iter.Next() {
+				m[iter.Key().String()] = iter.Value().Interface()
+			}
+			return writeCanonicalObject(buf, path, m)
+		}
+	}
+	// Fall back to encoding/json for structs and types with their own marshalling.
+	b, err := json.Marshal(v)
+	if err != nil {
+		return &InvalidTypeError{Prop: formatPointer(path), Expected: "JSON value", Actual: v, Cause: err}
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var decoded interface{}
+	if err := dec.Decode(&decoded); err != nil {
+		return &InvalidTypeError{Prop: formatPointer(path), Expected: "JSON value", Actual: v, Cause: err}
+	}
+	return writeCanonical(buf, path, decoded)
+}
+
+func writeCanonicalObject(buf *bytes.Buffer, path []string, m map[string]interface{}) error {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	// RFC 8785 orders members by UTF-16 code units, which differs from byte order for
+	// characters outside the Basic Multilingual Plane.
+	units := make(map[string][]uint16, len(keys))
+	for _, k := range keys {
+		units[k] = utf16.Encode([]rune(k))
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := units[keys[i]], units[keys[j]]
+		for n := 0; n < len(a) && n < len(b); n++ {
+			if a[n] != b[n] {
+				return a[n] < b[n]
+			}
+		}
+		return len(a) < len(b)
+	})
+	buf.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		child := append(path[:len(path):len(path)], k)
+		if err := writeCanonicalString(buf, child, k); err != nil {
+			return err
+		}
+		buf.WriteByte(':')
+		if err := writeCanonical(buf, child, m[k]); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+func writeCanonicalString(buf *bytes.Buffer, path []string, s string) error {
+	if !utf8.ValidString(s) {
+		return &ValidationError{Prop: formatPointer(path), Value: s, Reason: "string is not valid UTF-8"}
+	}
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte("0123456789abcdef"[r>>4])
+				buf.WriteByte("0123456789abcdef"[r&0xf])
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return nil
+}
+
+// writeCanonicalNumber formats f as ECMAScript's Number.prototype.toString does.
+func writeCanonicalNumber(buf *bytes.Buffer, path []string, v interface{}, f float64) error {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return &ValidationError{Prop: formatPointer(path), Value: v, Reason: "JSON cannot represent NaN or infinity"}
+	}
+	if f == 0 {
+		buf.WriteByte('0')
+		return nil
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs < 1e-6 || abs >= 1e21 {
+		format = 'e'
+	}
+	s := strconv.FormatFloat(f, format, -1, 64)
+	if format == 'e' {
+		// Go pads exponents to two digits ("1e-07"); ECMAScript does not.
+		if n := len(s); n >= 4 && s[n-4] == 'e' && s[n-2] == '0' {
+			s = s[:n-2] + s[n-1:]
+		}
+	}
+	buf.WriteString(s)
+	return nil
+}
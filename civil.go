@@ -0,0 +1,416 @@
+package go_objectutils
+
+import (
+	"fmt"
+	"time"
+)
+
+// Date is a calendar date without a time of day or location, such as a birthday.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the calendar date of t in t's location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// ParseCivilDate parses an ISO 8601 calendar date in extended ("2006-01-02") or basic ("20060102") form.
+func ParseCivilDate(s string) (Date, error) {
+	for _, layout := range []string{"2006-01-02", "20060102"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return DateOf(t), nil
+		}
+	}
+	return Date{}, fmt.Errorf("cannot parse '%s' as an ISO 8601 date", s)
+}
+
+// String returns the date in ISO 8601 extended form.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsValid reports whether d is a real calendar date.
+func (d Date) IsValid() bool {
+	return DateOf(d.In(time.UTC)) == d
+}
+
+// In returns the time at midnight at the start of d in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// At combines d with a time of day in loc.
+func (d Date) At(t TimeOfDay, loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, t.Hour, t.Minute, t.Second, t.Nanosecond, loc)
+}
+
+// Compare returns -1, 0 or +1 depending on whether d is before, equal to or after other.
+func (d Date) Compare(other Date) int {
+	switch {
+	case d.Year != other.Year:
+		return compareInt(d.Year, other.Year)
+	case d.Month != other.Month:
+		return compareInt(int(d.Month), int(other.Month))
+	}
+	return compareInt(d.Day, other.Day)
+}
+
+// Before reports whether d is before other.
+func (d Date) Before(other Date) bool {
+	return d.Compare(other) < 0
+}
+
+// After reports whether d is after other.
+func (d Date) After(other Date) bool {
+	return d.Compare(other) > 0
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Date) UnmarshalText(data []byte) error {
+	parsed, err := ParseCivilDate(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// TimeOfDay is a wall clock time without a date or location, such as a store opening time.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// TimeOfDayOf returns the wall clock time of t in t's location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second(), Nanosecond: t.Nanosecond()}
+}
+
+// ParseTimeOfDay parses an ISO 8601 time of day such as "09:30", "09:30:15", "09:30:15.5" or "093015".
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	for _, layout := range []string{"15:04:05", "15:04", "150405", "1504"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return TimeOfDayOf(t), nil
+		}
+	}
+	return TimeOfDay{}, fmt.Errorf("cannot parse '%s' as an ISO 8601 time of day", s)
+}
+
+// String returns the time in ISO 8601 extended form, including fractional seconds only when present.
+func (t TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond != 0 {
+		frac := fmt.Sprintf("%09d", t.Nanosecond)
+		for frac[len(frac)-1] == '0' {
+			frac = frac[:len(frac)-1]
+		}
+		s += "." + frac
+	}
+	return s
+}
+
+// IsValid reports whether t is within 00:00:00 and 23:59:59.999999999.
+func (t TimeOfDay) IsValid() bool {
+	return t.Hour >= 0 && t.Hour < 24 && t.Minute >= 0 && t.Minute < 60 &&
+		t.Second >= 0 && t.Second < 60 && t.Nanosecond >= 0 && t.Nanosecond < int(time.Second)
+}
+
+// On combines t with a date in loc.
+func (t TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	return d.At(t, loc)
+}
+
+// Compare returns -1, 0 or +1 depending on whether t is before, equal to or after other.
+func (t TimeOfDay) Compare(other TimeOfDay) int {
+	switch {
+	case t.Hour != other.Hour:
+		return compareInt(t.Hour, other.Hour)
+	case t.Minute != other.Minute:
+		return compareInt(t.Minute, other.Minute)
+	case t.Second != other.Second:
+		return compareInt(t.Second, other.Second)
+	}
+	return compareInt(t.Nanosecond, other.Nanosecond)
+}
+
+// Before reports whether t is before other.
+func (t TimeOfDay) Before(other TimeOfDay) bool {
+	return t.Compare(other) < 0
+}
+
+// After reports whether t is after other.
+func (t TimeOfDay) After(other TimeOfDay) bool {
+	return t.Compare(other) > 0
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *TimeOfDay) UnmarshalText(data []byte) error {
+	parsed, err := ParseTimeOfDay(string(data))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// parseCivilDate tries to convert interface{} to a Date.
+func parseCivilDate(val interface{}) (Date, error) {
+	switch v := val.(type) {
+	case Date:
+		if !v.IsValid() {
+			return Date{}, fmt.Errorf("%s is not a valid date", v)
+		}
+		return v, nil
+	case time.Time:
+		return DateOf(v), nil
+	case string:
+		return ParseCivilDate(v)
+	}
+	return Date{}, fmt.Errorf("cannot parse %T as date", val)
+}
+
+// parseTimeOfDay tries to convert interface{} to a TimeOfDay.
+func parseTimeOfDay(val interface{}) (TimeOfDay, error) {
+	switch v := val.(type) {
+	case TimeOfDay:
+		if !v.IsValid() {
+			return TimeOfDay{}, fmt.Errorf("%s is not a valid time of day", v)
+		}
+		return v, nil
+	case time.Time:
+		return TimeOfDayOf(v), nil
+	case string:
+		return ParseTimeOfDay(v)
+	}
+	return TimeOfDay{}, fmt.Errorf("cannot parse %T as time of day", val)
+}
+
+// GetCivilDate retrieves a calendar date property such as "1990-04-12".
+func GetCivilDate(props map[string]interface{}, prop string) (Date, error) {
+	if props == nil {
+		return Date{}, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return Date{}, &MissingFieldError{Prop: prop}
+	}
+	if d, err := parseCivilDate(val); err == nil {
+		return d, nil
+	} else {
+		return Date{}, &InvalidTypeError{Prop: prop, Expected: "date", Actual: val, Cause: err}
+	}
+}
+
+// MustGetCivilDate retrieves a calendar date property or panics.
+func MustGetCivilDate(props map[string]interface{}, prop string) Date {
+	val, err := GetCivilDate(props, prop)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetCivilDateOrDefault retrieves a calendar date property or returns a default value.
+func GetCivilDateOrDefault(props map[string]interface{}, prop string, defaultValue Date) Date {
+	val, err := GetCivilDate(props, prop)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetCivilDatePtr retrieves a calendar date property as a pointer.
+func GetCivilDatePtr(props map[string]interface{}, prop string) (*Date, error) {
+	val, err := GetCivilDate(props, prop)
+	if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// MustGetCivilDatePtr retrieves a calendar date property as a pointer or panics.
+func MustGetCivilDatePtr(props map[string]interface{}, prop string) *Date {
+	val, err := GetCivilDatePtr(props, prop)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetCivilDatePtrOrDefault retrieves a calendar date property as a pointer or returns a default value.
+func GetCivilDatePtrOrDefault(props map[string]interface{}, prop string, defaultValue *Date) *Date {
+	val, err := GetCivilDatePtr(props, prop)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetCivilDateArray retrieves a calendar date array property.
+func GetCivilDateArray(props map[string]interface{}, prop string) ([]Date, error) {
+	if props == nil {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	if arr, ok := toInterfaceSlice(val); ok {
+		res := make([]Date, len(arr))
+		for i, v := range arr {
+			if d, err := parseCivilDate(v); err == nil {
+				res[i] = d
+			} else {
+				return nil, &ElementError{Prop: prop, Indices: []int{i}, Expected: "date", Actual: v, Cause: err}
+			}
+		}
+		return res, nil
+	}
+	return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
+}
+
+// MustGetCivilDateArray retrieves a calendar date array property or panics.
+func MustGetCivilDateArray(props map[string]interface{}, prop string) []Date {
+	val, err := GetCivilDateArray(props, prop)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetCivilDateArrayOrDefault retrieves a calendar date array property or returns a default value.
+func GetCivilDateArrayOrDefault(props map[string]interface{}, prop string, defaultValue []Date) []Date {
+	val, err := GetCivilDateArray(props, prop)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetTimeOfDay retrieves a time of day property such as "09:30".
+func GetTimeOfDay(props map[string]interface{}, prop string) (TimeOfDay, error) {
+	if props == nil {
+		return TimeOfDay{}, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return TimeOfDay{}, &MissingFieldError{Prop: prop}
+	}
+	if t, err := parseTimeOfDay(val); err == nil {
+		return t, nil
+	} else {
+		return TimeOfDay{}, &InvalidTypeError{Prop: prop, Expected: "time of day", Actual: val, Cause: err}
+	}
+}
+
+// MustGetTimeOfDay retrieves a time of day property or panics.
+func MustGetTimeOfDay(props map[string]interface{}, prop string) TimeOfDay {
+	val, err := GetTimeOfDay(props, prop)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetTimeOfDayOrDefault retrieves a time of day property or returns a default value.
+func GetTimeOfDayOrDefault(props map[string]interface{}, prop string, defaultValue TimeOfDay) TimeOfDay {
+	val, err := GetTimeOfDay(props, prop)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetTimeOfDayPtr retrieves a time of day property as a pointer.
+func GetTimeOfDayPtr(props map[string]interface{}, prop string) (*TimeOfDay, error) {
+	val, err := GetTimeOfDay(props, prop)
+	if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// MustGetTimeOfDayPtr retrieves a time of day property as a pointer or panics.
+func MustGetTimeOfDayPtr(props map[string]interface{}, prop string) *TimeOfDay {
+	val, err := GetTimeOfDayPtr(props, prop)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetTimeOfDayPtrOrDefault retrieves a time of day property as a pointer or returns a default value.
+func GetTimeOfDayPtrOrDefault(props map[string]interface{}, prop string, defaultValue *TimeOfDay) *TimeOfDay {
+	val, err := GetTimeOfDayPtr(props, prop)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetTimeOfDayArray retrieves a time of day array property.
+func GetTimeOfDayArray(props map[string]interface{}, prop string) ([]TimeOfDay, error) {
+	if props == nil {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	if arr, ok := toInterfaceSlice(val); ok {
+		res := make([]TimeOfDay, len(arr))
+		for i, v := range arr {
+			if t, err := parseTimeOfDay(v); err == nil {
+				res[i] = t
+			} else {
+				return nil, &ElementError{Prop: prop, Indices: []int{i}, Expected: "time of day", Actual: v, Cause: err}
+			}
+		}
+		return res, nil
+	}
+	return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
+}
+
+// MustGetTimeOfDayArray retrieves a time of day array property or panics.
+func MustGetTimeOfDayArray(props map[string]interface{}, prop string) []TimeOfDay {
+	val, err := GetTimeOfDayArray(props, prop)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetTimeOfDayArrayOrDefault retrieves a time of day array property or returns a default value.
+func GetTimeOfDayArrayOrDefault(props map[string]interface{}, prop string, defaultValue []TimeOfDay) []TimeOfDay {
+	val, err := GetTimeOfDayArray(props, prop)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
@@ -0,0 +1,152 @@
+package go_objectutils
+
+import (
+	"fmt"
+	"image/color"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Color is a non-alpha-premultiplied 8-bit RGBA color. It implements color.Color.
+type Color struct {
+	R, G, B, A uint8
+}
+
+// RGBA implements color.Color.
+func (c Color) RGBA() (r, g, b, a uint32) {
+	return color.NRGBA{R: c.R, G: c.G, B: c.B, A: c.A}.RGBA()
+}
+
+// String returns the color as "#rrggbb", or "#rrggbbaa" when it is not fully opaque.
+func (c Color) String() string {
+	if c.A == 0xff {
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+}
+
+var (
+	hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+	rgbColorPattern = regexp.MustCompile(`^rgba?\(\s*([^,\s]+)\s*,\s*([^,\s]+)\s*,\s*([^,\s]+)\s*(?:,\s*([^,\s]+)\s*)?\)$`)
+)
+
+// ParseColor parses "#rgb", "#rgba", "#rrggbb", "#rrggbbaa", "rgb(r, g, b)" and "rgba(r, g, b, a)".
+// rgb components are 0-255 or percentages; alpha is 0-1 or a percentage.
+func ParseColor(s string) (Color, error) {
+	s = strings.TrimSpace(s)
+	if m := hexColorPattern.FindStringSubmatch(s); m != nil {
+		h := m[1]
+		if len(h) <= 4 {
+			var expanded strings.Builder
+			for _, r := range h {
+				expanded.WriteRune(r)
+				expanded.WriteRune(r)
+			}
+			h = expanded.String()
+		}
+		if len(h) == 6 {
+			h += "ff"
+		}
+		n, _ := strconv.ParseUint(h, 16, 32)
+		return Color{R: uint8(n >> 24), G: uint8(n >> 16), B: uint8(n >> 8), A: uint8(n)}, nil
+	}
+	m := rgbColorPattern.FindStringSubmatch(strings.ToLower(s))
+	if m == nil {
+		return Color{}, fmt.Errorf("cannot parse '%s' as a color", s)
+	}
+	if strings.HasPrefix(strings.ToLower(s), "rgba") != (m[4] != "") {
+		return Color{}, fmt.Errorf("cannot parse '%s' as a color: wrong number of components", s)
+	}
+	c := Color{A: 0xff}
+	for i, dst := range []*uint8{&c.R, &c.G, &c.B} {
+		v, err := parseColorComponent(m[i+1], 255)
+		if err != nil {
+			return Color{}, fmt.Errorf("cannot parse '%s' as a color: %w", s, err)
+		}
+		*dst = v
+	}
+	if m[4] != "" {
+		v, err := parseColorComponent(m[4], 1)
+		if err != nil {
+			return Color{}, fmt.Errorf("cannot parse '%s' as a color: %w", s, err)
+		}
+		c.A = v
+	}
+	return c, nil
+}
+
+// parseColorComponent parses a number in [0, max] or a percentage and scales it to 0-255.
+func parseColorComponent(s string, max float64) (uint8, error) {
+	scale := 255 / max
+	if strings.HasSuffix(s, "%") {
+		s = strings.TrimSuffix(s, "%")
+		max = 100
+		scale = 2.55
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("component '%s' is not a number", s)
+	}
+	if !(f >= 0 && f <= max) {
+		return 0, fmt.Errorf("component '%s' is out of range", s)
+	}
+	return uint8(f*scale + 0.5), nil
+}
+
+// convertToColor converts a color.Color or color string.
+func convertToColor(val interface{}) (Color, error) {
+	switch v := val.(type) {
+	case Color:
+		return v, nil
+	case string:
+		return ParseColor(v)
+	case color.Color:
+		n := color.NRGBAModel.Convert(v).(color.NRGBA)
+		return Color{R: n.R, G: n.G, B: n.B, A: n.A}, nil
+	}
+	return Color{}, fmt.Errorf("cannot convert %T to color", val)
+}
+
+// GetColor retrieves a color property given as a hex or rgb()/rgba() string.
+func GetColor(props map[string]interface{}, prop string) (Color, error) {
+	if props == nil {
+		return Color{}, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return Color{}, &MissingFieldError{Prop: prop}
+	}
+	if c, err := convertToColor(val); err == nil {
+		return c, nil
+	} else {
+		return Color{}, &InvalidTypeError{Prop: prop, Expected: "color", Actual: val, Cause: err}
+	}
+}
+
+// MustGetColor retrieves a color property or panics.
+func MustGetColor(props map[string]interface{}, prop string) Color {
+	val, err := GetColor(props, prop)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetColorOrDefault retrieves a color property or returns a default value.
+func GetColorOrDefault(props map[string]interface{}, prop string, defaultValue Color) Color {
+	val, err := GetColor(props, prop)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetColorPtr retrieves a color property as a pointer.
+func GetColorPtr(props map[string]interface{}, prop string) (*Color, error) {
+	val, err := GetColor(props, prop)
+	if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
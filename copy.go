@@ -0,0 +1,278 @@
+package go_objectutils
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// GetOptions configures getters whose results can share state with props.
+// It is accepted by the object, map and array getters in every form (Must, OrDefault,
+// Ptr, pointer-array and legacy PropOrDefault). Getters that already take another
+// variadic argument, such as the 2D array getters and the PropOrThrow aliases, do not
+// accept it; wrap their result in DeepCopy instead.
+//
+// Adding the options parameter changed the function types of these getters, so code that
+// stores one as a func(map[string]interface{}, string) (T, error) value must wrap it in a closure.
+type GetOptions struct {
+	// Copy returns a deep copy, so mutating the result does not change props.
+	Copy bool
+}
+
+// wantsCopy reports whether any of opts asks for a copy.
+func wantsCopy(opts []GetOptions) bool {
+	for _, opt := range opts {
+		if opt.Copy {
+			return true
+		}
+	}
+	return false
+}
+
+// copyResult deep copies v when any of opts asks for it.
+func copyResult[T any](v T, opts []GetOptions) T {
+	if wantsCopy(opts) {
+		return DeepCopy(v)
+	}
+	return v
+}
+
+// copyPointers copies arr and the values its elements point to when any of opts asks for it.
+func copyPointers[T any](arr []*T, opts []GetOptions) []*T {
+	if arr == nil || !wantsCopy(opts) {
+		return arr
+	}
+	res := make([]*T, len(arr))
+	for i, p := range arr {
+		if p != nil {
+			c := DeepCopy(*p)
+			res[i] = &c
+		}
+	}
+	return res
+}
+
+// DeepCopy returns a copy of v in which no map or slice is shared with v, including those held
+// in arrays. Other values, such as pointers and structs, are copied shallowly.
+func DeepCopy[T any](v T) T {
+	if c, ok := copyValue(v).(T); ok {
+		return c
+	}
+	return v
+}
+
+// copyValue returns a copy of v in which nested maps and slices are not shared with v.
+func copyValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if t == nil {
+			return t
+		}
+		res := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			res[k] = copyValue(e)
+		}
+		return res
+	case []interface{}:
+		if t == nil {
+			return t
+		}
+		res := make([]interface{}, len(t))
+		for i, e := range t {
+			res[i] = copyValue(e)
+		}
+		return res
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.IsNil() {
+			return v
+		}
+		res := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			res.Index(i).Set(copyReflect(rv.Index(i)))
+		}
+		return res.Interface()
+	case reflect.Array:
+		res := reflect.New(rv.Type()).Elem()
+		for i := 0; i < rv.Len(); i++ {
+			res.Index(i).Set(copyReflect(rv.Index(i)))
+		}
+		return res.Interface()
+	case reflect.Map:
+		if rv.IsNil() {
+			return v
+		}
+		res := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			res.SetMapIndex(iter.Key(), copyReflect(iter.Value()))
+		}
+		return res.Interface()
+	}
+	return v
+}
+
+// copyReflect copies a slice element or map value, keeping its static type.
+func copyReflect(v reflect.Value) reflect.Value {
+	c := copyValue(v.Interface())
+	if c == nil {
+		return reflect.Zero(v.Type())
+	}
+	return reflect.ValueOf(c)
+}
+
+// DeepEqual reports whether a and b hold the same JSON-shaped value. Numbers are compared by
+// value regardless of Go type, including json.Number, and typed slices and maps compare equal to
+// their []interface{} and map[string]interface{} forms.
+func DeepEqual(a, b interface{}) bool {
+	return valuesEqual(normalizeValue(a, false), normalizeValue(b, false))
+}
+
+// Normalize converts doc into the shape encoding/json produces when decoding into interface{}:
+// numbers of any Go type and json.Number become float64, typed slices become []interface{}, and
+// maps with string or integer keys become map[string]interface{}. Pointers are dereferenced.
+// Integers beyond 2^53 lose precision, as they do in encoding/json.
+func Normalize(doc map[string]interface{}) map[string]interface{} {
+	res, _ := normalizeValue(doc, true).(map[string]interface{})
+	return res
+}
+
+// normalizeValue converts v into JSON-shaped values, converting numbers to float64 when numbers is set.
+func normalizeValue(v interface{}, numbers bool) interface{} {
+	if n, ok := v.(json.Number); ok {
+		if !numbers {
+			return n
+		}
+		if f, err := n.Float64(); err == nil {
+			return f
+		}
+		return string(n)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if !numbers {
+			return v
+		}
+		return float64Of(rv)
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return normalizeValue(rv.Elem().Interface(), numbers)
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil
+		}
+		res := make([]interface{}, rv.Len())
+		for i := range res {
+			res[i] = normalizeValue(rv.Index(i).Interface(), numbers)
+		}
+		return res
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil
+		}
+		res := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			k := iter.Key()
+			var key string
+			switch k.Kind() {
+			case reflect.String:
+				key = k.String()
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				key = strconv.FormatInt(k.Int(), 10)
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				key = strconv.FormatUint(k.Uint(), 10)
+			default:
+				return v
+			}
+			res[key] = normalizeValue(iter.Value().Interface(), numbers)
+		}
+		return res
+	}
+	return v
+}
+
+// valuesEqual compares two decoded JSON values, treating numbers of different Go types as equal
+// when they hold the same value.
+func valuesEqual(a, b interface{}) bool {
+	if ar, ok := exactNumber(a); ok {
+		br, ok := exactNumber(b)
+		return ok && ar.Cmp(br) == 0
+	}
+	if am, ok := a.(map[string]interface{}); ok {
+		bm, ok := b.(map[string]interface{})
+		if !ok || len(am) != len(bm) {
+			return false
+		}
+		for k, av := range am {
+			bv, ok := bm[k]
+			if !ok || !valuesEqual(av, bv) {
+				return false
+			}
+		}
+		return true
+	}
+	if aa, ok := toInterfaceSlice(a); ok {
+		ba, ok := toInterfaceSlice(b)
+		if !ok || len(aa) != len(ba) {
+			return false
+		}
+		for i := range aa {
+			if !valuesEqual(aa[i], ba[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// exactNumber returns the exact value of a Go numeric type or json.Number. NaN and infinities
+// are not numbers here, so they never compare equal.
+func exactNumber(v interface{}) (*big.Rat, bool) {
+	if n, ok := v.(json.Number); ok {
+		return new(big.Rat).SetString(string(n))
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Rat).SetUint64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		if r := new(big.Rat).SetFloat64(rv.Float()); r != nil {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// jsonNumber returns the value of a Go numeric type or json.Number as a float64.
+func jsonNumber(v interface{}) (float64, bool) {
+	if n, ok := v.(json.Number); ok {
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return float64Of(rv), true
+	}
+	return 0, false
+}
@@ -0,0 +1,203 @@
+package go_objectutils
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// ChangeType identifies the kind of a Change.
+type ChangeType int
+
+const (
+	// Added means the path exists only in the second document.
+	Added ChangeType = iota
+	// Removed means the path exists only in the first document.
+	Removed
+	// Changed means the path holds different values in the two documents.
+	Changed
+)
+
+// String returns "added", "removed" or "changed".
+func (t ChangeType) String() string {
+	switch t {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	}
+	return "ChangeType(" + strconv.Itoa(int(t)) + ")"
+}
+
+// Change is a single difference between two documents. Path is a JSON Pointer; Old is unset for
+// additions and New is unset for removals.
+type Change struct {
+	Type ChangeType
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+// DiffOptions configures Diff.
+type DiffOptions struct {
+	// NumericEquivalence treats numbers of different Go types, such as int 1 and float64 1.0, as equal.
+	NumericEquivalence bool
+	// ArrayKey, when set, matches elements of arrays of objects by this field instead of by index.
+	// Element order is then not compared. Arrays whose elements do not all have a unique key
+	// are compared by index.
+	ArrayKey string
+}
+
+// Diff lists the changes that turn a into b. Object members are visited in sorted key order and
+// the changes are ordered so that Patch renders a valid RFC 6902 patch from them.
+func Diff(a, b map[string]interface{}, opts ...DiffOptions) []Change {
+	var opt DiffOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	var changes []Change
+	diffValues(&changes, nil, a, b, opt)
+	return changes
+}
+
+// DiffPatch renders changes as an RFC 6902 patch of add, remove and replace operations.
+func DiffPatch(changes []Change) Patch {
+	res := make(Patch, len(changes))
+	for i, c := range changes {
+		switch c.Type {
+		case Added:
+			res[i] = PatchOperation{Op: "add", Path: c.Path, Value: c.New}
+		case Removed:
+			res[i] = PatchOperation{Op: "remove", Path: c.Path}
+		default:
+			res[i] = PatchOperation{Op: "replace", Path: c.Path, Value: c.New}
+		}
+	}
+	return res
+}
+
+func diffValues(changes *[]Change, path []string, a, b interface{}, opt DiffOptions) {
+	am, aObj := a.(map[string]interface{})
+	bm, bObj := b.(map[string]interface{})
+	if aObj && bObj {
+		diffObjects(changes, path, am, bm, opt)
+		return
+	}
+	aa, aArr := toInterfaceSlice(a)
+	ba, bArr := toInterfaceSlice(b)
+	if aArr && bArr {
+		if opt.ArrayKey != "" && keyedArray(aa, opt.ArrayKey) && keyedArray(ba, opt.ArrayKey) {
+			diffKeyedArrays(changes, path, aa, ba, opt)
+		} else {
+			diffArrays(changes, path, aa, ba, opt)
+		}
+		return
+	}
+	if !scalarsEqual(a, b, opt) {
+		*changes = append(*changes, Change{Type: Changed, Path: formatPointer(path), Old: a, New: b})
+	}
+}
+
+func diffObjects(changes *[]Change, path []string, a, b map[string]interface{}, opt DiffOptions) {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		av, inA := a[k]
+		bv, inB := b[k]
+		child := append(path[:len(path):len(path)], k)
+		switch {
+		case !inB:
+			*changes = append(*changes, Change{Type: Removed, Path: formatPointer(child), Old: av})
+		case !inA:
+			*changes = append(*changes, Change{Type: Added, Path: formatPointer(child), New: bv})
+		default:
+			diffValues(changes, child, av, bv, opt)
+		}
+	}
+}
+
+// diffArrays compares arrays position by position. Surplus elements of a are removed from the
+// end backwards so each removal index stays valid when applied as a patch.
+func diffArrays(changes *[]Change, path []string, a, b []interface{}, opt DiffOptions) {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		diffValues(changes, append(path[:len(path):len(path)], strconv.Itoa(i)), a[i], b[i], opt)
+	}
+	for i := len(a) - 1; i >= n; i-- {
+		*changes = append(*changes, Change{Type: Removed, Path: formatPointer(append(path[:len(path):len(path)], strconv.Itoa(i))), Old: a[i]})
+	}
+	for i := n; i < len(b); i++ {
+		*changes = append(*changes, Change{Type: Added, Path: formatPointer(append(path[:len(path):len(path)], strconv.Itoa(i))), New: b[i]})
+	}
+}
+
+// diffKeyedArrays matches elements by key. Unmatched elements of a are removed first, matched
+// elements are then compared at their positions among the survivors, and unmatched elements of
+// b are appended.
+func diffKeyedArrays(changes *[]Change, path []string, a, b []interface{}, opt DiffOptions) {
+	at := func(i int) []string { return append(path[:len(path):len(path)], strconv.Itoa(i)) }
+	var survivors []int
+	for i := len(a) - 1; i >= 0; i-- {
+		if keyIndex(b, opt.ArrayKey, a[i].(map[string]interface{})[opt.ArrayKey]) < 0 {
+			*changes = append(*changes, Change{Type: Removed, Path: formatPointer(at(i)), Old: a[i]})
+		} else {
+			survivors = append([]int{i}, survivors...)
+		}
+	}
+	for j, i := range survivors {
+		bi := keyIndex(b, opt.ArrayKey, a[i].(map[string]interface{})[opt.ArrayKey])
+		diffValues(changes, at(j), a[i], b[bi], opt)
+	}
+	next := len(survivors)
+	for _, v := range b {
+		if keyIndex(a, opt.ArrayKey, v.(map[string]interface{})[opt.ArrayKey]) < 0 {
+			*changes = append(*changes, Change{Type: Added, Path: formatPointer(at(next)), New: v})
+			next++
+		}
+	}
+}
+
+// keyedArray reports whether every element of arr is an object with a unique value for key.
+func keyedArray(arr []interface{}, key string) bool {
+	for i, v := range arr {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		k, ok := m[key]
+		if !ok || keyIndex(arr[:i], key, k) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// keyIndex returns the index of the element of arr whose key field equals value, or -1.
+func keyIndex(arr []interface{}, key string, value interface{}) int {
+	for i, v := range arr {
+		if valuesEqual(v.(map[string]interface{})[key], value) {
+			return i
+		}
+	}
+	return -1
+}
+
+func scalarsEqual(a, b interface{}, opt DiffOptions) bool {
+	if opt.NumericEquivalence {
+		if ar, ok := exactNumber(a); ok {
+			br, ok := exactNumber(b)
+			return ok && ar.Cmp(br) == 0
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,86 @@
+package go_objectutils
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// EmailOptions configures GetEmail.
+type EmailOptions struct {
+	// AddrSpecOnly rejects addresses with a display name or angle brackets,
+	// accepting only a bare "local@domain".
+	AddrSpecOnly bool
+}
+
+// parseEmail parses an RFC 5322 address, reporting errors against prop.
+func parseEmail(prop string, val interface{}, opts []EmailOptions) (*mail.Address, error) {
+	s, ok := val.(string)
+	if !ok {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "email address", Actual: val}
+	}
+	addr, err := mail.ParseAddress(s)
+	if err != nil {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "email address", Actual: val, Cause: err}
+	}
+	for _, opt := range opts {
+		if opt.AddrSpecOnly && addr.Address != strings.TrimSpace(s) {
+			return nil, &ValidationError{Prop: prop, Value: val, Reason: fmt.Sprintf("'%s' is not a bare address", s)}
+		}
+	}
+	return addr, nil
+}
+
+// GetEmail retrieves an email address property such as "Alice <alice@example.com>" using net/mail.
+func GetEmail(props map[string]interface{}, prop string, opts ...EmailOptions) (*mail.Address, error) {
+	if props == nil {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	return parseEmail(prop, val, opts)
+}
+
+// MustGetEmail retrieves an email address property or panics.
+func MustGetEmail(props map[string]interface{}, prop string, opts ...EmailOptions) *mail.Address {
+	val, err := GetEmail(props, prop, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetEmailOrDefault retrieves an email address property or returns a default value.
+func GetEmailOrDefault(props map[string]interface{}, prop string, defaultValue *mail.Address, opts ...EmailOptions) *mail.Address {
+	val, err := GetEmail(props, prop, opts...)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetEmailArray retrieves an array of email addresses.
+func GetEmailArray(props map[string]interface{}, prop string, opts ...EmailOptions) ([]*mail.Address, error) {
+	if props == nil {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	arr, ok := toInterfaceSlice(val)
+	if !ok {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
+	}
+	res := make([]*mail.Address, len(arr))
+	for i, v := range arr {
+		addr, err := parseEmail(fmt.Sprintf("%s[%d]", prop, i), v, opts)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = addr
+	}
+	return res, nil
+}
@@ -0,0 +1,300 @@
+package go_objectutils
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// EnumConstraint defines the underlying types an enum can be declared with,
+// such as `type Status string` or `type Level int`.
+type EnumConstraint interface {
+	~string | NumberConstraint
+}
+
+// Enum is a set of allowed values for T, with optional names, aliases and case folding.
+// Values of string kind are automatically accepted by their own text; other kinds may be
+// given names with Name so they can also be read from strings.
+// Enums should be fully configured before they are used for lookups.
+type Enum[T EnumConstraint] struct {
+	values    []T
+	allowed   map[T]struct{}
+	names     map[string]T
+	canonical map[T]string
+	// folded indexes names by their lower-cased form once CaseInsensitive is set.
+	folded map[string]T
+}
+
+// NewEnum creates an Enum accepting the given values.
+func NewEnum[T EnumConstraint](values ...T) *Enum[T] {
+	e := &Enum[T]{
+		allowed:   map[T]struct{}{},
+		names:     map[string]T{},
+		canonical: map[T]string{},
+	}
+	for _, v := range values {
+		e.add(v)
+	}
+	return e
+}
+
+func (e *Enum[T]) add(v T) {
+	if _, ok := e.allowed[v]; ok {
+		return
+	}
+	e.values = append(e.values, v)
+	e.allowed[v] = struct{}{}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.String {
+		e.register(rv.String(), v)
+		e.canonical[v] = rv.String()
+	}
+}
+
+// register accepts name for value. It panics if name is already registered for a different
+// value or, with case folding enabled, folds to the same text as such a name, as lookups could
+// not tell them apart.
+func (e *Enum[T]) register(name string, value T) {
+	if other, ok := e.names[name]; ok && other != value {
+		panic(fmt.Sprintf("go_objectutils: enum name %q is already registered for %q", name, e.NameOf(other)))
+	}
+	e.names[name] = value
+	if e.folded == nil {
+		return
+	}
+	key := strings.ToLower(name)
+	if other, ok := e.folded[key]; ok && other != value {
+		panic(fmt.Sprintf("go_objectutils: enum name %q matches the name of %q when case is ignored", name, e.NameOf(other)))
+	}
+	e.folded[key] = value
+}
+
+// Name registers the canonical name of value, adding value to the enum if needed.
+// Any aliases are also accepted when reading.
+func (e *Enum[T]) Name(value T, name string, aliases ...string) *Enum[T] {
+	e.add(value)
+	e.register(name, value)
+	e.canonical[value] = name
+	for _, alias := range aliases {
+		e.register(alias, value)
+	}
+	return e
+}
+
+// Alias registers an additional name accepted for value.
+func (e *Enum[T]) Alias(alias string, value T) *Enum[T] {
+	e.add(value)
+	e.register(alias, value)
+	return e
+}
+
+// CaseInsensitive makes name and alias matching ignore case. An exact match is still
+// preferred. It panics if two names of different values differ only in case.
+func (e *Enum[T]) CaseInsensitive() *Enum[T] {
+	if e.folded != nil {
+		return e
+	}
+	e.folded = map[string]T{}
+	names := make([]string, 0, len(e.names))
+	for name := range e.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		e.register(name, e.names[name])
+	}
+	return e
+}
+
+// Values returns the allowed values in registration order.
+func (e *Enum[T]) Values() []T {
+	return append([]T(nil), e.values...)
+}
+
+// NameOf returns the canonical name of value, or its default formatting if it has none.
+func (e *Enum[T]) NameOf(value T) string {
+	if name, ok := e.canonical[value]; ok {
+		return name
+	}
+	return fmt.Sprint(value)
+}
+
+// allowedNames lists the allowed values for error messages.
+func (e *Enum[T]) allowedNames() []string {
+	res := make([]string, len(e.values))
+	for i, v := range e.values {
+		res[i] = e.NameOf(v)
+	}
+	return res
+}
+
+// lookupName finds the value registered under name.
+func (e *Enum[T]) lookupName(name string) (T, bool) {
+	if v, ok := e.names[name]; ok {
+		return v, true
+	}
+	if e.folded != nil {
+		if v, ok := e.folded[strings.ToLower(name)]; ok {
+			return v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Parse converts val into an allowed value of the enum, reporting failures against prop.
+func (e *Enum[T]) Parse(prop string, val interface{}) (T, error) {
+	var zero T
+	if v, ok := val.(T); ok {
+		if _, ok := e.allowed[v]; ok {
+			return v, nil
+		}
+	}
+	if s, ok := val.(string); ok {
+		if v, ok := e.lookupName(s); ok {
+			return v, nil
+		}
+	}
+	var num T
+	rv := reflect.ValueOf(&num).Elem()
+	if rv.Kind() != reflect.String {
+		if f, err := convertToNumber[float64](val); err == nil {
+			switch rv.Kind() {
+			case reflect.Float32, reflect.Float64:
+				rv.SetFloat(f)
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				if f >= 0 {
+					rv.SetUint(uint64(f))
+				}
+			default:
+				rv.SetInt(int64(f))
+			}
+			// Reject values that do not survive conversion, such as 1.5 for an integer enum.
+			if _, ok := e.allowed[num]; ok && float64Of(rv) == f {
+				return num, nil
+			}
+		}
+	}
+	return zero, &InvalidEnumError{Prop: prop, Value: val, Allowed: e.allowedNames()}
+}
+
+// float64Of returns the numeric value held by rv as a float64.
+func float64Of(rv reflect.Value) float64 {
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	}
+	return float64(rv.Int())
+}
+
+// GetEnum retrieves a property constrained to the values of enum.
+func GetEnum[T EnumConstraint](props map[string]interface{}, prop string, enum *Enum[T]) (T, error) {
+	var zero T
+	if props == nil {
+		return zero, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return zero, &MissingFieldError{Prop: prop}
+	}
+	return enum.Parse(prop, val)
+}
+
+// MustGetEnum retrieves an enum property or panics.
+func MustGetEnum[T EnumConstraint](props map[string]interface{}, prop string, enum *Enum[T]) T {
+	val, err := GetEnum(props, prop, enum)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetEnumOrDefault retrieves an enum property or returns a default value.
+func GetEnumOrDefault[T EnumConstraint](props map[string]interface{}, prop string, enum *Enum[T], defaultValue T) T {
+	val, err := GetEnum(props, prop, enum)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetEnumPtr retrieves an enum property as a pointer.
+func GetEnumPtr[T EnumConstraint](props map[string]interface{}, prop string, enum *Enum[T]) (*T, error) {
+	val, err := GetEnum(props, prop, enum)
+	if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetEnumArray retrieves an array property whose elements are constrained to the values of enum.
+func GetEnumArray[T EnumConstraint](props map[string]interface{}, prop string, enum *Enum[T]) ([]T, error) {
+	if props == nil {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	arr, ok := toInterfaceSlice(val)
+	if !ok {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
+	}
+	res := make([]T, len(arr))
+	for i, v := range arr {
+		e, err := enum.Parse(fmt.Sprintf("%s[%d]", prop, i), v)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = e
+	}
+	return res, nil
+}
+
+// MustGetEnumArray retrieves an enum array property or panics.
+func MustGetEnumArray[T EnumConstraint](props map[string]interface{}, prop string, enum *Enum[T]) []T {
+	val, err := GetEnumArray(props, prop, enum)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetEnumArrayOrDefault retrieves an enum array property or returns a default value.
+func GetEnumArrayOrDefault[T EnumConstraint](props map[string]interface{}, prop string, enum *Enum[T], defaultValue []T) []T {
+	val, err := GetEnumArray(props, prop, enum)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetEnumSet retrieves an enum array property as a set. Duplicate values are merged unless
+// RejectDuplicates is given.
+func GetEnumSet[T EnumConstraint](props map[string]interface{}, prop string, enum *Enum[T], mode ...SetMode) (map[T]struct{}, error) {
+	arr, err := GetEnumArray(props, prop, enum)
+	if err != nil {
+		return nil, err
+	}
+	return toSet(prop, arr, mode...)
+}
+
+// MustGetEnumSet retrieves an enum set property or panics.
+func MustGetEnumSet[T EnumConstraint](props map[string]interface{}, prop string, enum *Enum[T], mode ...SetMode) map[T]struct{} {
+	val, err := GetEnumSet(props, prop, enum, mode...)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetEnumSetOrDefault retrieves an enum set property or returns a default value.
+func GetEnumSetOrDefault[T EnumConstraint](props map[string]interface{}, prop string, enum *Enum[T], defaultValue map[T]struct{}, mode ...SetMode) map[T]struct{} {
+	val, err := GetEnumSet(props, prop, enum, mode...)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
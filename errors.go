@@ -1,6 +1,9 @@
 package go_objectutils
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // MissingFieldError indicates that a required field is missing from the map.
 type MissingFieldError struct {
@@ -40,3 +43,152 @@ type RegexMismatchError struct {
 func (e *RegexMismatchError) Error() string {
 	return fmt.Sprintf("property '%s' value '%s' does not match regex '%s'", e.Prop, e.Value, e.Expression)
 }
+
+// ElementError indicates that an element within an array property is invalid.
+// Indices holds the position of the element, outermost array first.
+type ElementError struct {
+	Prop     string
+	Indices  []int
+	Expected string
+	Actual   interface{}
+	Cause    error
+}
+
+func (e *ElementError) Error() string {
+	pos := ""
+	for _, i := range e.Indices {
+		pos += fmt.Sprintf("[%d]", i)
+	}
+	if e.Cause != nil {
+		return fmt.Sprintf("property '%s' element %s is not of type %s, got %T: %v", e.Prop, pos, e.Expected, e.Actual, e.Cause)
+	}
+	return fmt.Sprintf("property '%s' element %s is not of type %s, got %T", e.Prop, pos, e.Expected, e.Actual)
+}
+
+func (e *ElementError) Unwrap() error {
+	return e.Cause
+}
+
+// ShapeError indicates that an array property does not have the expected shape.
+// Index is the position of the offending inner array, or -1 when the outer array is at fault.
+type ShapeError struct {
+	Prop     string
+	Index    int
+	Expected int
+	Actual   int
+}
+
+func (e *ShapeError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("property '%s' has length %d, expected %d", e.Prop, e.Actual, e.Expected)
+	}
+	return fmt.Sprintf("property '%s' element [%d] has length %d, expected %d", e.Prop, e.Index, e.Actual, e.Expected)
+}
+
+// UnknownVariantError indicates that a discriminator field holds a value with no registered variant.
+type UnknownVariantError struct {
+	Prop     string
+	Value    string
+	Accepted []string
+}
+
+func (e *UnknownVariantError) Error() string {
+	return fmt.Sprintf("property '%s' value '%s' is not a known variant, expected one of: %s", e.Prop, e.Value, strings.Join(e.Accepted, ", "))
+}
+
+// ConstructorError indicates that a constructor function failed to build a value from an object property.
+// Index is the position of the element within an array property, or -1 for a single object.
+type ConstructorError struct {
+	Prop  string
+	Index int
+	Cause error
+}
+
+func (e *ConstructorError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("property '%s' could not be constructed: %v", e.Prop, e.Cause)
+	}
+	return fmt.Sprintf("property '%s' element [%d] could not be constructed: %v", e.Prop, e.Index, e.Cause)
+}
+
+func (e *ConstructorError) Unwrap() error {
+	return e.Cause
+}
+
+// InvalidEnumError indicates that a field holds a value outside of an enum's allowed set.
+type InvalidEnumError struct {
+	Prop    string
+	Value   interface{}
+	Allowed []string
+}
+
+func (e *InvalidEnumError) Error() string {
+	return fmt.Sprintf("property '%s' value '%v' is not one of: %s", e.Prop, e.Value, strings.Join(e.Allowed, ", "))
+}
+
+// DuplicateElementError indicates that an array read as a set contains the same value more than once.
+type DuplicateElementError struct {
+	Prop        string
+	Value       interface{}
+	FirstIndex  int
+	SecondIndex int
+}
+
+func (e *DuplicateElementError) Error() string {
+	return fmt.Sprintf("property '%s' value '%v' is duplicated at elements [%d] and [%d]", e.Prop, e.Value, e.FirstIndex, e.SecondIndex)
+}
+
+// ValidationError indicates that a field has the expected type but fails a validation rule.
+type ValidationError struct {
+	Prop   string
+	Value  interface{}
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("property '%s' is invalid: %s", e.Prop, e.Reason)
+}
+
+// CronFieldError indicates that a field of a cron expression is invalid.
+type CronFieldError struct {
+	Expression string
+	Field      string
+	Value      string
+	Reason     string
+}
+
+func (e *CronFieldError) Error() string {
+	return fmt.Sprintf("cron expression '%s' field %s '%s' is invalid: %s", e.Expression, e.Field, e.Value, e.Reason)
+}
+
+// IndexError indicates that an array index in a path is outside the array.
+type IndexError struct {
+	Prop   string
+	Index  int
+	Length int
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("property '%s' index %d is out of range for length %d", e.Prop, e.Index, e.Length)
+}
+
+// KeyCollisionError indicates that two flat keys address the same or overlapping locations,
+// such as "a" and "a.b".
+type KeyCollisionError struct {
+	Key   string
+	Other string
+}
+
+func (e *KeyCollisionError) Error() string {
+	return fmt.Sprintf("keys '%s' and '%s' collide", e.Key, e.Other)
+}
+
+// AmbiguousKeyError indicates that a loose key lookup matched more than one key.
+type AmbiguousKeyError struct {
+	Prop    string
+	Matches []string
+}
+
+func (e *AmbiguousKeyError) Error() string {
+	return fmt.Sprintf("property '%s' is ambiguous, matching: %s", e.Prop, strings.Join(e.Matches, ", "))
+}
@@ -0,0 +1,238 @@
+package go_objectutils
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// IndexStyle controls how Flatten and Unflatten write array indices in flat keys.
+type IndexStyle int
+
+const (
+	// DotIndex writes indices as their own segment, as in "a.0.b". Keys made only of digits
+	// are escaped with a backslash so they are not read back as indices.
+	DotIndex IndexStyle = iota
+	// BracketIndex writes indices in brackets, as in "a[0].b".
+	BracketIndex
+)
+
+// indexStyle returns the last style given, defaulting to DotIndex.
+func indexStyle(style []IndexStyle) IndexStyle {
+	res := DotIndex
+	for _, s := range style {
+		res = s
+	}
+	return res
+}
+
+// Flatten converts doc into a single-level map whose keys join the path to each leaf with sep,
+// which defaults to ".". A backslash escapes key characters that would otherwise be read as part
+// of a separator or index. Empty objects and arrays are kept as leaf values.
+func Flatten(doc map[string]interface{}, sep string, style ...IndexStyle) map[string]interface{} {
+	if sep == "" {
+		sep = "."
+	}
+	res := map[string]interface{}{}
+	for k, v := range doc {
+		flattenValue(res, escapeFlatKey(k, sep, indexStyle(style)), v, sep, indexStyle(style))
+	}
+	return res
+}
+
+func flattenValue(res map[string]interface{}, prefix string, v interface{}, sep string, style IndexStyle) {
+	if m, ok := v.(map[string]interface{}); ok && len(m) > 0 {
+		for k, e := range m {
+			flattenValue(res, prefix+sep+escapeFlatKey(k, sep, style), e, sep, style)
+		}
+		return
+	}
+	if _, ok := v.([]byte); !ok {
+		if arr, ok := toInterfaceSlice(v); ok && len(arr) > 0 {
+			for i, e := range arr {
+				if style == BracketIndex {
+					flattenValue(res, prefix+"["+strconv.Itoa(i)+"]", e, sep, style)
+				} else {
+					flattenValue(res, prefix+sep+strconv.Itoa(i), e, sep, style)
+				}
+			}
+			return
+		}
+	}
+	res[prefix] = v
+}
+
+// escapeFlatKey escapes backslashes, any character of sep and, depending on style, brackets or
+// a leading digit of an all-digit key.
+func escapeFlatKey(key string, sep string, style IndexStyle) string {
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if c == '\\' || strings.IndexByte(sep, c) >= 0 || (style == BracketIndex && c == '[') {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	if style == DotIndex && isDigits(key) {
+		return "\\" + b.String()
+	}
+	return b.String()
+}
+
+func isDigits(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+// parseFlatKey splits a flat key into path segments.
+func parseFlatKey(key string, sep string, style IndexStyle) ([]pathSegment, error) {
+	var segs []pathSegment
+	var cur strings.Builder
+	escaped := false
+	// afterIndex is set after a bracketed index, where only a separator, another index or the
+	// end of the key may follow.
+	afterIndex := false
+	flush := func() error {
+		if afterIndex && cur.Len() == 0 && !escaped {
+			afterIndex = false
+			return nil
+		}
+		if cur.Len() == 0 {
+			return fmt.Errorf("flat key '%s' has an empty segment", key)
+		}
+		if style == DotIndex && !escaped && isDigits(cur.String()) {
+			n, err := strconv.Atoi(cur.String())
+			if err != nil {
+				return err
+			}
+			segs = append(segs, pathSegment{isIndex: true, index: n})
+		} else {
+			segs = append(segs, pathSegment{key: cur.String()})
+		}
+		cur.Reset()
+		escaped, afterIndex = false, false
+		return nil
+	}
+	for i := 0; i < len(key); i++ {
+		switch {
+		case key[i] == '\\':
+			if i+1 >= len(key) {
+				return nil, fmt.Errorf("flat key '%s' ends with an escape", key)
+			}
+			i++
+			cur.WriteByte(key[i])
+			escaped = true
+		case strings.HasPrefix(key[i:], sep):
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			i += len(sep) - 1
+		case style == BracketIndex && key[i] == '[':
+			if !afterIndex || cur.Len() > 0 {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+			}
+			end := strings.IndexByte(key[i:], ']')
+			if end < 0 || !isDigits(key[i+1:i+end]) {
+				return nil, fmt.Errorf("flat key '%s' has an invalid index", key)
+			}
+			n, err := strconv.Atoi(key[i+1 : i+end])
+			if err != nil {
+				return nil, err
+			}
+			segs = append(segs, pathSegment{isIndex: true, index: n})
+			i += end
+			afterIndex = true
+		default:
+			if afterIndex {
+				return nil, fmt.Errorf("flat key '%s' has text after an index", key)
+			}
+			cur.WriteByte(key[i])
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return segs, nil
+}
+
+// Unflatten rebuilds a nested document from a map produced by Flatten or read from a flat
+// source such as environment variables. Keys that address the same or overlapping locations,
+// such as "a" and "a.b", or that use one location as both an array and an object, such as
+// "a.0" and "a.b", return a *KeyCollisionError. Array indices must be contiguous from zero.
+func Unflatten(flat map[string]interface{}, sep string, style ...IndexStyle) (map[string]interface{}, error) {
+	if sep == "" {
+		sep = "."
+	}
+	type entry struct {
+		key  string
+		segs []pathSegment
+	}
+	entries := make([]entry, 0, len(flat))
+	for k := range flat {
+		segs, err := parseFlatKey(k, sep, indexStyle(style))
+		if err != nil {
+			return nil, err
+		}
+		if segs[0].isIndex {
+			return nil, &InvalidTypeError{Prop: k, Expected: "object key", Actual: k}
+		}
+		entries = append(entries, entry{k, segs})
+	}
+	// Sorting by segments visits array indices in ascending order so arrays grow contiguously.
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i].segs, entries[j].segs
+		for n := 0; n < len(a) && n < len(b); n++ {
+			if a[n] == b[n] {
+				continue
+			}
+			if a[n].isIndex && b[n].isIndex {
+				return a[n].index < b[n].index
+			}
+			if a[n].isIndex != b[n].isIndex {
+				return a[n].isIndex
+			}
+			return a[n].key < b[n].key
+		}
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return entries[i].key < entries[j].key
+	})
+	// prefix records a key passing through a location and whether it indexes the location as
+	// an array or as an object.
+	type prefix struct {
+		key   string
+		array bool
+	}
+	leaves := map[string]string{}
+	prefixes := map[string]prefix{}
+	for _, e := range entries {
+		path := formatPath(e.segs)
+		if other, ok := leaves[path]; ok {
+			return nil, &KeyCollisionError{Key: e.key, Other: other}
+		}
+		if other, ok := prefixes[path]; ok {
+			return nil, &KeyCollisionError{Key: e.key, Other: other.key}
+		}
+		for i := 1; i < len(e.segs); i++ {
+			p := formatPath(e.segs[:i])
+			if other, ok := leaves[p]; ok {
+				return nil, &KeyCollisionError{Key: e.key, Other: other}
+			}
+			if other, ok := prefixes[p]; ok && other.array != e.segs[i].isIndex {
+				return nil, &KeyCollisionError{Key: e.key, Other: other.key}
+			}
+			prefixes[p] = prefix{key: e.key, array: e.segs[i].isIndex}
+		}
+		leaves[path] = e.key
+	}
+	res := map[string]interface{}{}
+	for _, e := range entries {
+		if _, err := setSegments(res, e.segs, 0, flat[e.key]); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
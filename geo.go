@@ -0,0 +1,164 @@
+package go_objectutils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GeoPoint is a WGS 84 latitude and longitude in degrees.
+type GeoPoint struct {
+	Lat float64
+	Lng float64
+}
+
+// String returns the point as "lat,lng".
+func (p GeoPoint) String() string {
+	return strconv.FormatFloat(p.Lat, 'f', -1, 64) + "," + strconv.FormatFloat(p.Lng, 'f', -1, 64)
+}
+
+// validate checks that the point is within latitude ±90 and longitude ±180.
+func (p GeoPoint) validate(prop string, val interface{}) error {
+	if !(p.Lat >= -90 && p.Lat <= 90) {
+		return &ValidationError{Prop: prop, Value: val, Reason: fmt.Sprintf("latitude %v is outside ±90", p.Lat)}
+	}
+	if !(p.Lng >= -180 && p.Lng <= 180) {
+		return &ValidationError{Prop: prop, Value: val, Reason: fmt.Sprintf("longitude %v is outside ±180", p.Lng)}
+	}
+	return nil
+}
+
+// parseGeoPoint converts an object {lat, lng}, an array [lng, lat] or a "lat,lng" string.
+func parseGeoPoint(prop string, val interface{}) (GeoPoint, error) {
+	var p GeoPoint
+	switch v := val.(type) {
+	case GeoPoint:
+		p = v
+	case map[string]interface{}:
+		lat, err := firstNumber(v, "lat", "latitude")
+		if err != nil {
+			return GeoPoint{}, &InvalidTypeError{Prop: prop, Expected: "geo point", Actual: val, Cause: err}
+		}
+		lng, err := firstNumber(v, "lng", "lon", "long", "longitude")
+		if err != nil {
+			return GeoPoint{}, &InvalidTypeError{Prop: prop, Expected: "geo point", Actual: val, Cause: err}
+		}
+		p = GeoPoint{Lat: lat, Lng: lng}
+	case string:
+		lat, lng, ok := strings.Cut(v, ",")
+		if !ok {
+			return GeoPoint{}, &InvalidTypeError{Prop: prop, Expected: "geo point", Actual: val, Cause: fmt.Errorf("expected 'lat,lng'")}
+		}
+		var err error
+		if p.Lat, err = strconv.ParseFloat(strings.TrimSpace(lat), 64); err != nil {
+			return GeoPoint{}, &InvalidTypeError{Prop: prop, Expected: "geo point", Actual: val, Cause: err}
+		}
+		if p.Lng, err = strconv.ParseFloat(strings.TrimSpace(lng), 64); err != nil {
+			return GeoPoint{}, &InvalidTypeError{Prop: prop, Expected: "geo point", Actual: val, Cause: err}
+		}
+	default:
+		// Arrays follow GeoJSON order: [lng, lat].
+		pair, err := GetPair[float64, float64](map[string]interface{}{prop: val}, prop)
+		if err != nil {
+			return GeoPoint{}, err
+		}
+		p = GeoPoint{Lat: pair.Second, Lng: pair.First}
+	}
+	if err := p.validate(prop, val); err != nil {
+		return GeoPoint{}, err
+	}
+	return p, nil
+}
+
+// firstNumber returns the first of keys present in props as a float64.
+func firstNumber(props map[string]interface{}, keys ...string) (float64, error) {
+	for _, k := range keys {
+		if _, ok := props[k]; ok {
+			return GetNumber[float64](props, k)
+		}
+	}
+	return 0, &MissingFieldError{Prop: keys[0]}
+}
+
+// GetGeoPoint retrieves a latitude/longitude property given as an object {lat, lng},
+// a GeoJSON-ordered array [lng, lat] or a "lat,lng" string. Coordinates out of range
+// return a *ValidationError.
+func GetGeoPoint(props map[string]interface{}, prop string) (GeoPoint, error) {
+	if props == nil {
+		return GeoPoint{}, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return GeoPoint{}, &MissingFieldError{Prop: prop}
+	}
+	return parseGeoPoint(prop, val)
+}
+
+// MustGetGeoPoint retrieves a geo point property or panics.
+func MustGetGeoPoint(props map[string]interface{}, prop string) GeoPoint {
+	val, err := GetGeoPoint(props, prop)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetGeoPointOrDefault retrieves a geo point property or returns a default value.
+func GetGeoPointOrDefault(props map[string]interface{}, prop string, defaultValue GeoPoint) GeoPoint {
+	val, err := GetGeoPoint(props, prop)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetGeoPointPtr retrieves a geo point property as a pointer.
+func GetGeoPointPtr(props map[string]interface{}, prop string) (*GeoPoint, error) {
+	val, err := GetGeoPoint(props, prop)
+	if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetGeoPointArray retrieves an array of geo points, such as a path or polygon ring.
+func GetGeoPointArray(props map[string]interface{}, prop string) ([]GeoPoint, error) {
+	if props == nil {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	arr, ok := toInterfaceSlice(val)
+	if !ok {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
+	}
+	res := make([]GeoPoint, len(arr))
+	for i, v := range arr {
+		p, err := parseGeoPoint(fmt.Sprintf("%s[%d]", prop, i), v)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = p
+	}
+	return res, nil
+}
+
+// MustGetGeoPointArray retrieves a geo point array property or panics.
+func MustGetGeoPointArray(props map[string]interface{}, prop string) []GeoPoint {
+	val, err := GetGeoPointArray(props, prop)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetGeoPointArrayOrDefault retrieves a geo point array property or returns a default value.
+func GetGeoPointArrayOrDefault(props map[string]interface{}, prop string, defaultValue []GeoPoint) []GeoPoint {
+	val, err := GetGeoPointArray(props, prop)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
@@ -0,0 +1,278 @@
+package go_objectutils
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// KeyMatch controls how FindKey and GetMatching compare property names with map keys.
+type KeyMatch int
+
+const (
+	// ExactKeys matches keys exactly, as the getters do.
+	ExactKeys KeyMatch = iota
+	// CaseInsensitiveKeys matches keys ignoring case, so "userId" matches "UserID".
+	CaseInsensitiveKeys
+	// ConventionInsensitiveKeys matches keys ignoring case and word separators, so "userId"
+	// matches "user_id", "UserID" and "user-id".
+	ConventionInsensitiveKeys
+)
+
+// foldKey reduces key to the form compared under mode.
+func foldKey(key string, mode KeyMatch) string {
+	switch mode {
+	case CaseInsensitiveKeys:
+		return strings.ToLower(key)
+	case ConventionInsensitiveKeys:
+		return strings.ToLower(strings.Map(func(r rune) rune {
+			if r == '_' || r == '-' || r == ' ' {
+				return -1
+			}
+			return r
+		}, key))
+	}
+	return key
+}
+
+// FindKey returns the key of props that matches prop under mode. An exact match is always
+// preferred. It returns a *MissingFieldError when nothing matches and an *AmbiguousKeyError when
+// several keys match.
+func FindKey(props map[string]interface{}, prop string, mode KeyMatch) (string, error) {
+	if props == nil {
+		return "", &MissingFieldError{Prop: prop}
+	}
+	if _, ok := props[prop]; ok {
+		return prop, nil
+	}
+	if mode == ExactKeys {
+		return "", &MissingFieldError{Prop: prop}
+	}
+	want := foldKey(prop, mode)
+	var matches []string
+	for k := range props {
+		if foldKey(k, mode) == want {
+			matches = append(matches, k)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", &MissingFieldError{Prop: prop}
+	case 1:
+		return matches[0], nil
+	}
+	sort.Strings(matches)
+	return "", &AmbiguousKeyError{Prop: prop, Matches: matches}
+}
+
+// MatchKeys returns a shallow copy of props in which each of names is also present under its own
+// spelling when a key matches it under mode. Any getter, including those taking options, can then
+// read the names from the result. Names that match no key are left absent, so getters report them
+// as missing; an *AmbiguousKeyError is returned when several keys match one name.
+func MatchKeys(props map[string]interface{}, mode KeyMatch, names ...string) (map[string]interface{}, error) {
+	res := make(map[string]interface{}, len(props)+len(names))
+	for k, v := range props {
+		res[k] = v
+	}
+	for _, name := range names {
+		key, err := FindKey(props, name, mode)
+		if err != nil {
+			if _, ok := err.(*MissingFieldError); ok {
+				continue
+			}
+			return nil, err
+		}
+		res[name] = props[key]
+	}
+	return res, nil
+}
+
+// GetMatching retrieves prop with getter after resolving its key under mode, for example
+// GetMatching(props, "userId", ConventionInsensitiveKeys, GetString). The getter must have the form
+// func(map[string]interface{}, string) (T, error); wrap getters that take options in a closure or
+// read them from MatchKeys.
+func GetMatching[T any](props map[string]interface{}, prop string, mode KeyMatch, getter func(map[string]interface{}, string) (T, error)) (T, error) {
+	key, err := FindKey(props, prop, mode)
+	if err != nil {
+		if _, ok := err.(*MissingFieldError); ok {
+			return getter(props, prop)
+		}
+		var zero T
+		return zero, err
+	}
+	return getter(props, key)
+}
+
+// MustGetMatching retrieves a loosely matched property or panics.
+func MustGetMatching[T any](props map[string]interface{}, prop string, mode KeyMatch, getter func(map[string]interface{}, string) (T, error)) T {
+	val, err := GetMatching(props, prop, mode, getter)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetMatchingOrDefault retrieves a loosely matched property or returns a default value.
+func GetMatchingOrDefault[T any](props map[string]interface{}, prop string, mode KeyMatch, getter func(map[string]interface{}, string) (T, error), defaultValue T) T {
+	val, err := GetMatching(props, prop, mode, getter)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// KeyConvention is a naming convention for object keys.
+type KeyConvention int
+
+const (
+	// CamelCase writes keys like "userId".
+	CamelCase KeyConvention = iota
+	// PascalCase writes keys like "UserId".
+	PascalCase
+	// SnakeCase writes keys like "user_id".
+	SnakeCase
+	// KebabCase writes keys like "user-id".
+	KebabCase
+)
+
+// splitWords splits key into words at underscores, hyphens, spaces and case changes.
+// A run of capitals is one word, so "HTTPServer" splits into "HTTP" and "Server".
+func splitWords(key string) []string {
+	var words []string
+	runes := []rune(key)
+	start := 0
+	for i := 0; i <= len(runes); i++ {
+		if i == len(runes) || runes[i] == '_' || runes[i] == '-' || runes[i] == ' ' {
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		}
+		if i > start && unicode.IsUpper(runes[i]) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || nextLower {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+	}
+	return words
+}
+
+// ConvertKey rewrites key in the given convention.
+func ConvertKey(key string, convention KeyConvention) string {
+	words := splitWords(key)
+	if len(words) == 0 {
+		return key
+	}
+	for i, w := range words {
+		w = strings.ToLower(w)
+		if convention == PascalCase || (convention == CamelCase && i > 0) {
+			r := []rune(w)
+			r[0] = unicode.ToUpper(r[0])
+			w = string(r)
+		}
+		words[i] = w
+	}
+	switch convention {
+	case SnakeCase:
+		return strings.Join(words, "_")
+	case KebabCase:
+		return strings.Join(words, "-")
+	}
+	return strings.Join(words, "")
+}
+
+// RewriteKeys returns a copy of doc with every object key, including those in nested objects,
+// arrays, typed slices and maps with string keys, converted to convention. Two keys of one object
+// that convert to the same name return a *KeyCollisionError. doc is not modified.
+func RewriteKeys(doc map[string]interface{}, convention KeyConvention) (map[string]interface{}, error) {
+	res, err := rewriteKeys(doc, convention)
+	if err != nil {
+		return nil, err
+	}
+	m, _ := res.(map[string]interface{})
+	return m, nil
+}
+
+// rewriteKeys converts the keys of every map below v. Typed slices and maps keep their Go types.
+func rewriteKeys(v interface{}, convention KeyConvention) (interface{}, error) {
+	if m, ok := v.(map[string]interface{}); ok {
+		if m == nil {
+			return m, nil
+		}
+		res := make(map[string]interface{}, len(m))
+		from := make(map[string]string, len(m))
+		for _, k := range sortedKeys(m) {
+			nk := ConvertKey(k, convention)
+			if other, ok := from[nk]; ok {
+				return nil, &KeyCollisionError{Key: k, Other: other}
+			}
+			child, err := rewriteKeys(m[k], convention)
+			if err != nil {
+				return nil, err
+			}
+			from[nk] = k
+			res[nk] = child
+		}
+		return res, nil
+	}
+	if _, ok := v.([]byte); ok {
+		return copyValue(v), nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.IsNil() {
+			return v, nil
+		}
+		res := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			child, err := rewriteKeys(rv.Index(i).Interface(), convention)
+			if err != nil {
+				return nil, err
+			}
+			res.Index(i).Set(reflectValueOf(child, rv.Type().Elem()))
+		}
+		return res.Interface(), nil
+	case reflect.Map:
+		if rv.IsNil() {
+			return v, nil
+		}
+		keyType := rv.Type().Key()
+		res := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface()) })
+		from := make(map[string]string, len(keys))
+		for _, k := range keys {
+			child, err := rewriteKeys(rv.MapIndex(k).Interface(), convention)
+			if err != nil {
+				return nil, err
+			}
+			nk := k
+			if keyType.Kind() == reflect.String {
+				name := ConvertKey(k.String(), convention)
+				if other, ok := from[name]; ok {
+					return nil, &KeyCollisionError{Key: k.String(), Other: other}
+				}
+				from[name] = k.String()
+				nk = reflect.ValueOf(name).Convert(keyType)
+			}
+			res.SetMapIndex(nk, reflectValueOf(child, rv.Type().Elem()))
+		}
+		return res.Interface(), nil
+	}
+	return copyValue(v), nil
+}
+
+// reflectValueOf wraps v for storing in a slot of type t, using the zero value for nil.
+func reflectValueOf(v interface{}, t reflect.Type) reflect.Value {
+	if v == nil {
+		return reflect.Zero(t)
+	}
+	return reflect.ValueOf(v)
+}
@@ -0,0 +1,119 @@
+package go_objectutils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var offsetPattern = regexp.MustCompile(`^([+-])(\d{2})(?::?(\d{2}))?$`)
+
+// ParseLocation parses an IANA zone name such as "Australia/Melbourne", "UTC" or "Z",
+// or a fixed UTC offset such as "+10:00", "-0530" or "+10".
+// Zone names are resolved with time.LoadLocation, which needs zone data on the host
+// unless the binary is built with the objectutils_tzdata (or Go's timetzdata) build tag.
+func ParseLocation(s string) (*time.Location, error) {
+	if s == "" {
+		return nil, fmt.Errorf("empty location")
+	}
+	if s == "Z" {
+		return time.UTC, nil
+	}
+	if m := offsetPattern.FindStringSubmatch(s); m != nil {
+		hours, _ := strconv.Atoi(m[2])
+		minutes := 0
+		if m[3] != "" {
+			minutes, _ = strconv.Atoi(m[3])
+		}
+		if hours > 18 || minutes > 59 {
+			return nil, fmt.Errorf("offset '%s' is out of range", s)
+		}
+		offset := hours*60*60 + minutes*60
+		if m[1] == "-" {
+			offset = -offset
+		}
+		return time.FixedZone(s, offset), nil
+	}
+	return time.LoadLocation(s)
+}
+
+// convertToLocation converts a *time.Location or string to a location.
+func convertToLocation(val interface{}) (*time.Location, error) {
+	switch v := val.(type) {
+	case *time.Location:
+		if v == nil {
+			return nil, fmt.Errorf("nil location")
+		}
+		return v, nil
+	case string:
+		return ParseLocation(v)
+	}
+	return nil, fmt.Errorf("cannot convert %T to location", val)
+}
+
+// GetLocation retrieves a time zone property given as an IANA zone name or a fixed UTC offset.
+func GetLocation(props map[string]interface{}, prop string) (*time.Location, error) {
+	if props == nil {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	if loc, err := convertToLocation(val); err == nil {
+		return loc, nil
+	} else {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "time.Location", Actual: val, Cause: err}
+	}
+}
+
+// MustGetLocation retrieves a time zone property or panics.
+func MustGetLocation(props map[string]interface{}, prop string) *time.Location {
+	val, err := GetLocation(props, prop)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetLocationOrDefault retrieves a time zone property or returns a default value.
+func GetLocationOrDefault(props map[string]interface{}, prop string, defaultValue *time.Location) *time.Location {
+	val, err := GetLocation(props, prop)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetDateIn retrieves a date property and re-expresses it in the location held by
+// the sibling property locationProp, e.g. {"start": "...", "timezone": "Australia/Melbourne"}.
+func GetDateIn(props map[string]interface{}, prop string, locationProp string) (time.Time, error) {
+	t, err := GetDate(props, prop)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc, err := GetLocation(props, locationProp)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.In(loc), nil
+}
+
+// MustGetDateIn retrieves a date property in a sibling location or panics.
+func MustGetDateIn(props map[string]interface{}, prop string, locationProp string) time.Time {
+	val, err := GetDateIn(props, prop, locationProp)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetDateInOrDefault retrieves a date property in a sibling location or returns a default value.
+func GetDateInOrDefault(props map[string]interface{}, prop string, locationProp string, defaultValue time.Time) time.Time {
+	val, err := GetDateIn(props, prop, locationProp)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
@@ -0,0 +1,169 @@
+package go_objectutils
+
+import (
+	"reflect"
+)
+
+// MergePatch applies an RFC 7386 JSON Merge Patch to a copy of doc and returns the result.
+// Objects in patch are merged recursively, null members delete the matching key, and any
+// other value, including arrays, replaces the target value.
+func MergePatch(doc map[string]interface{}, patch map[string]interface{}) map[string]interface{} {
+	return mergePatchObject(doc, patch)
+}
+
+func mergePatchObject(target interface{}, patch map[string]interface{}) map[string]interface{} {
+	res, ok := copyValue(target).(map[string]interface{})
+	if !ok || res == nil {
+		res = map[string]interface{}{}
+	}
+	for k, v := range patch {
+		switch pv := v.(type) {
+		case nil:
+			delete(res, k)
+		case map[string]interface{}:
+			res[k] = mergePatchObject(res[k], pv)
+		default:
+			res[k] = copyValue(v)
+		}
+	}
+	return res
+}
+
+// ArrayStrategy controls how DeepMerge combines two arrays.
+type ArrayStrategy int
+
+const (
+	// ReplaceArrays uses the source array in place of the destination array.
+	ReplaceArrays ArrayStrategy = iota
+	// AppendArrays appends the source elements to the destination elements.
+	AppendArrays
+	// MergeArraysByKey deep merges object elements that share the same MergeOptions.Key value
+	// and appends the remaining source elements.
+	MergeArraysByKey
+)
+
+// MergeOptions configures DeepMerge.
+type MergeOptions struct {
+	Arrays ArrayStrategy
+	// Key is the field identifying array elements for MergeArraysByKey.
+	Key string
+	// OnConflict is called when the destination and source hold different kinds of value at path,
+	// such as an object and a string, and returns the value to keep. When nil the source wins.
+	OnConflict func(path string, dst, src interface{}) (interface{}, error)
+	// DeleteNulls removes destination keys whose source value is null, as MergePatch does.
+	// By default a null source value keeps the destination value.
+	DeleteNulls bool
+}
+
+// DeepMerge merges src over dst and returns the result without modifying either.
+// Objects are merged recursively, arrays are combined according to the array strategy and
+// other non-null source values replace destination values. A null source value keeps the
+// destination value unless DeleteNulls is set. Layers can be folded by merging repeatedly.
+func DeepMerge(dst, src map[string]interface{}, opts ...MergeOptions) (map[string]interface{}, error) {
+	var opt MergeOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	res, err := mergeValues(dst, src, nil, opt)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, nil
+	}
+	return res.(map[string]interface{}), nil
+}
+
+func mergeValues(dst, src interface{}, path []pathSegment, opt MergeOptions) (interface{}, error) {
+	if dst == nil {
+		return copyValue(src), nil
+	}
+	if src == nil {
+		return copyValue(dst), nil
+	}
+	dk, sk := valueKind(dst), valueKind(src)
+	if dk != sk {
+		if opt.OnConflict != nil {
+			return opt.OnConflict(formatPath(path), dst, src)
+		}
+		return copyValue(src), nil
+	}
+	switch dk {
+	case "object":
+		dm, sm := dst.(map[string]interface{}), src.(map[string]interface{})
+		res := copyValue(dm).(map[string]interface{})
+		if res == nil {
+			res = map[string]interface{}{}
+		}
+		for k, sv := range sm {
+			if sv == nil && opt.DeleteNulls {
+				delete(res, k)
+				continue
+			}
+			v, err := mergeValues(res[k], sv, append(path, pathSegment{key: k}), opt)
+			if err != nil {
+				return nil, err
+			}
+			res[k] = v
+		}
+		return res, nil
+	case "array":
+		da, _ := toInterfaceSlice(dst)
+		sa, _ := toInterfaceSlice(src)
+		switch opt.Arrays {
+		case AppendArrays:
+			return copyValue(append(append([]interface{}(nil), da...), sa...)), nil
+		case MergeArraysByKey:
+			return mergeArraysByKey(da, sa, path, opt)
+		}
+	}
+	return copyValue(src), nil
+}
+
+// mergeArraysByKey merges elements of src into dst by the value of opt.Key.
+func mergeArraysByKey(dst, src []interface{}, path []pathSegment, opt MergeOptions) (interface{}, error) {
+	res := copyValue(dst).([]interface{})
+	for _, sv := range src {
+		matched := false
+		if sm, ok := sv.(map[string]interface{}); ok {
+			if key, ok := sm[opt.Key]; ok {
+				for i, dv := range res {
+					dm, ok := dv.(map[string]interface{})
+					if !ok || !valuesEqual(dm[opt.Key], key) {
+						continue
+					}
+					v, err := mergeValues(dm, sm, append(path, pathSegment{isIndex: true, index: i}), opt)
+					if err != nil {
+						return nil, err
+					}
+					res[i] = v
+					matched = true
+					break
+				}
+			}
+		}
+		if !matched {
+			res = append(res, copyValue(sv))
+		}
+	}
+	return res, nil
+}
+
+// valueKind classifies a decoded value as an object, array, string, number, boolean or other type.
+func valueKind(v interface{}) string {
+	if _, ok := v.(map[string]interface{}); ok {
+		return "object"
+	}
+	if _, ok := jsonNumber(v); ok {
+		return "number"
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	}
+	return reflect.TypeOf(v).String()
+}
@@ -0,0 +1,189 @@
+package go_objectutils
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ArrayShape describes constraints on the inner arrays of a nested array property.
+// The zero value accepts ragged arrays.
+type ArrayShape struct {
+	// Rectangular requires every inner array to have the same length as the first.
+	Rectangular bool
+	// InnerLength, when greater than zero, requires every inner array to have exactly this length.
+	InnerLength int
+}
+
+func (s ArrayShape) check(prop string, rows [][]interface{}) error {
+	for i, row := range rows {
+		if s.InnerLength > 0 && len(row) != s.InnerLength {
+			return &ShapeError{Prop: prop, Index: i, Expected: s.InnerLength, Actual: len(row)}
+		}
+		if s.Rectangular && len(row) != len(rows[0]) {
+			return &ShapeError{Prop: prop, Index: i, Expected: len(rows[0]), Actual: len(row)}
+		}
+	}
+	return nil
+}
+
+// GetNestedArray retrieves an array of arrays property, converting each inner element with convert.
+// Outer and inner values may be any slice or array kind. Errors for individual elements are
+// reported as *ElementError carrying the outer and inner indices.
+func GetNestedArray[T any](props map[string]interface{}, prop string, convert func(interface{}) (T, error), shape ...ArrayShape) ([][]T, error) {
+	if props == nil {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	outer, ok := toInterfaceSlice(val)
+	if !ok {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
+	}
+	rows := make([][]interface{}, len(outer))
+	for i, v := range outer {
+		inner, ok := toInterfaceSlice(v)
+		if !ok {
+			return nil, &ElementError{Prop: prop, Indices: []int{i}, Expected: "array", Actual: v}
+		}
+		rows[i] = inner
+	}
+	for _, s := range shape {
+		if err := s.check(prop, rows); err != nil {
+			return nil, err
+		}
+	}
+	res := make([][]T, len(rows))
+	for i, row := range rows {
+		res[i] = make([]T, len(row))
+		for j, v := range row {
+			converted, err := convert(v)
+			if err != nil {
+				return nil, &ElementError{Prop: prop, Indices: []int{i, j}, Expected: typeName[T](), Actual: v, Cause: err}
+			}
+			res[i][j] = converted
+		}
+	}
+	return res, nil
+}
+
+// MustGetNestedArray retrieves an array of arrays property or panics.
+func MustGetNestedArray[T any](props map[string]interface{}, prop string, convert func(interface{}) (T, error), shape ...ArrayShape) [][]T {
+	val, err := GetNestedArray(props, prop, convert, shape...)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetNestedArrayOrDefault retrieves an array of arrays property or returns a default value.
+func GetNestedArrayOrDefault[T any](props map[string]interface{}, prop string, convert func(interface{}) (T, error), defaultValue [][]T, shape ...ArrayShape) [][]T {
+	val, err := GetNestedArray(props, prop, convert, shape...)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetNumberArray2D retrieves a nested number array property such as [[1,2],[3,4]].
+func GetNumberArray2D[T NumberConstraint](props map[string]interface{}, prop string, shape ...ArrayShape) ([][]T, error) {
+	return GetNestedArray(props, prop, convertToNumber[T], shape...)
+}
+
+// MustGetNumberArray2D retrieves a nested number array property or panics.
+func MustGetNumberArray2D[T NumberConstraint](props map[string]interface{}, prop string, shape ...ArrayShape) [][]T {
+	return MustGetNestedArray(props, prop, convertToNumber[T], shape...)
+}
+
+// GetNumberArray2DOrDefault retrieves a nested number array property or returns a default value.
+func GetNumberArray2DOrDefault[T NumberConstraint](props map[string]interface{}, prop string, defaultValue [][]T, shape ...ArrayShape) [][]T {
+	return GetNestedArrayOrDefault(props, prop, convertToNumber[T], defaultValue, shape...)
+}
+
+// GetStringArray2D retrieves a nested string array property such as [["a"],["b","c"]].
+func GetStringArray2D(props map[string]interface{}, prop string, shape ...ArrayShape) ([][]string, error) {
+	return GetNestedArray(props, prop, convertToString, shape...)
+}
+
+// MustGetStringArray2D retrieves a nested string array property or panics.
+func MustGetStringArray2D(props map[string]interface{}, prop string, shape ...ArrayShape) [][]string {
+	return MustGetNestedArray(props, prop, convertToString, shape...)
+}
+
+// GetStringArray2DOrDefault retrieves a nested string array property or returns a default value.
+func GetStringArray2DOrDefault(props map[string]interface{}, prop string, defaultValue [][]string, shape ...ArrayShape) [][]string {
+	return GetNestedArrayOrDefault(props, prop, convertToString, defaultValue, shape...)
+}
+
+// GetDateArray2D retrieves a nested date array property.
+func GetDateArray2D(props map[string]interface{}, prop string, shape ...ArrayShape) ([][]time.Time, error) {
+	return GetNestedArray(props, prop, parseDate, shape...)
+}
+
+// MustGetDateArray2D retrieves a nested date array property or panics.
+func MustGetDateArray2D(props map[string]interface{}, prop string, shape ...ArrayShape) [][]time.Time {
+	return MustGetNestedArray(props, prop, parseDate, shape...)
+}
+
+// GetDateArray2DOrDefault retrieves a nested date array property or returns a default value.
+func GetDateArray2DOrDefault(props map[string]interface{}, prop string, defaultValue [][]time.Time, shape ...ArrayShape) [][]time.Time {
+	return GetNestedArrayOrDefault(props, prop, parseDate, defaultValue, shape...)
+}
+
+// GetObjectArray2D retrieves a nested object array property.
+func GetObjectArray2D[T any](props map[string]interface{}, prop string, shape ...ArrayShape) ([][]T, error) {
+	return GetNestedArray(props, prop, convertToObject[T], shape...)
+}
+
+// MustGetObjectArray2D retrieves a nested object array property or panics.
+func MustGetObjectArray2D[T any](props map[string]interface{}, prop string, shape ...ArrayShape) [][]T {
+	return MustGetNestedArray(props, prop, convertToObject[T], shape...)
+}
+
+// GetObjectArray2DOrDefault retrieves a nested object array property or returns a default value.
+func GetObjectArray2DOrDefault[T any](props map[string]interface{}, prop string, defaultValue [][]T, shape ...ArrayShape) [][]T {
+	return GetNestedArrayOrDefault(props, prop, convertToObject[T], defaultValue, shape...)
+}
+
+// convertToString converts an element to a string.
+func convertToString(val interface{}) (string, error) {
+	if s, ok := val.(string); ok {
+		return s, nil
+	}
+	if rv := reflect.ValueOf(val); rv.Kind() == reflect.String {
+		return rv.String(), nil
+	}
+	return "", fmt.Errorf("cannot convert %T to string", val)
+}
+
+// convertToBool converts a bool or value of a named bool type.
+func convertToBool(val interface{}) (bool, error) {
+	if b, ok := val.(bool); ok {
+		return b, nil
+	}
+	if rv := reflect.ValueOf(val); rv.Kind() == reflect.Bool {
+		return rv.Bool(), nil
+	}
+	return false, fmt.Errorf("cannot convert %T to bool", val)
+}
+
+// convertToObject casts an element to T.
+func convertToObject[T any](val interface{}) (T, error) {
+	if castVal, ok := val.(T); ok {
+		return castVal, nil
+	}
+	var zero T
+	// A null element is a valid value of any interface type.
+	if val == nil && reflect.TypeOf((*T)(nil)).Elem().Kind() == reflect.Interface {
+		return zero, nil
+	}
+	return zero, fmt.Errorf("cannot convert %T to %s", val, typeName[T]())
+}
+
+// typeName returns the name of T, which unlike formatting a zero value with %T is also correct
+// for interface types.
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
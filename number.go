@@ -2,6 +2,7 @@ package go_objectutils
 
 import (
 	"fmt"
+	"reflect"
 	"strconv"
 )
 
@@ -30,6 +31,22 @@ func convertToNumber[T NumberConstraint](val interface{}) (T, error) {
 		}
 		return T(f), nil
 	}
+	// Typed slices built by Go code yield other numeric kinds and named types.
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return T(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return T(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return T(rv.Float()), nil
+	case reflect.String:
+		f, err := strconv.ParseFloat(rv.String(), 64)
+		if err != nil {
+			return zero, err
+		}
+		return T(f), nil
+	}
 	return zero, fmt.Errorf("cannot convert %T to number", val)
 }
 
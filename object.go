@@ -1,9 +1,7 @@
 package go_objectutils
 
-import "fmt"
-
 // GetObject retrieves an object property (as T).
-func GetObject[T any](props map[string]interface{}, prop string) (T, error) {
+func GetObject[T any](props map[string]interface{}, prop string, opts ...GetOptions) (T, error) {
 	var zero T
 	if props == nil {
 		return zero, &MissingFieldError{Prop: prop}
@@ -13,14 +11,14 @@ func GetObject[T any](props map[string]interface{}, prop string) (T, error) {
 		return zero, &MissingFieldError{Prop: prop}
 	}
 	if castVal, ok := val.(T); ok {
-		return castVal, nil
+		return copyResult(castVal, opts), nil
 	}
-	return zero, &InvalidTypeError{Prop: prop, Expected: fmt.Sprintf("%T", zero), Actual: val}
+	return zero, &InvalidTypeError{Prop: prop, Expected: typeName[T](), Actual: val}
 }
 
 // MustGetObject retrieves an object property or panics.
-func MustGetObject[T any](props map[string]interface{}, prop string) T {
-	val, err := GetObject[T](props, prop)
+func MustGetObject[T any](props map[string]interface{}, prop string, opts ...GetOptions) T {
+	val, err := GetObject[T](props, prop, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -28,8 +26,8 @@ func MustGetObject[T any](props map[string]interface{}, prop string) T {
 }
 
 // GetObjectOrDefault retrieves an object property or returns a default value.
-func GetObjectOrDefault[T any](props map[string]interface{}, prop string, defaultValue T) T {
-	val, err := GetObject[T](props, prop)
+func GetObjectOrDefault[T any](props map[string]interface{}, prop string, defaultValue T, opts ...GetOptions) T {
+	val, err := GetObject[T](props, prop, opts...)
 	if err != nil {
 		return defaultValue
 	}
@@ -37,8 +35,8 @@ func GetObjectOrDefault[T any](props map[string]interface{}, prop string, defaul
 }
 
 // GetObjectPtr retrieves an object property as a pointer.
-func GetObjectPtr[T any](props map[string]interface{}, prop string) (*T, error) {
-	val, err := GetObject[T](props, prop)
+func GetObjectPtr[T any](props map[string]interface{}, prop string, opts ...GetOptions) (*T, error) {
+	val, err := GetObject[T](props, prop, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -46,8 +44,8 @@ func GetObjectPtr[T any](props map[string]interface{}, prop string) (*T, error)
 }
 
 // MustGetObjectPtr retrieves an object property as a pointer or panics.
-func MustGetObjectPtr[T any](props map[string]interface{}, prop string) *T {
-	val, err := GetObjectPtr[T](props, prop)
+func MustGetObjectPtr[T any](props map[string]interface{}, prop string, opts ...GetOptions) *T {
+	val, err := GetObjectPtr[T](props, prop, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -55,8 +53,8 @@ func MustGetObjectPtr[T any](props map[string]interface{}, prop string) *T {
 }
 
 // GetObjectPtrOrDefault retrieves an object property as a pointer or returns a default value.
-func GetObjectPtrOrDefault[T any](props map[string]interface{}, prop string, defaultValue *T) *T {
-	val, err := GetObjectPtr[T](props, prop)
+func GetObjectPtrOrDefault[T any](props map[string]interface{}, prop string, defaultValue *T, opts ...GetOptions) *T {
+	val, err := GetObjectPtr[T](props, prop, opts...)
 	if err != nil {
 		return defaultValue
 	}
@@ -64,7 +62,7 @@ func GetObjectPtrOrDefault[T any](props map[string]interface{}, prop string, def
 }
 
 // GetMap retrieves a map property.
-func GetMap[K comparable, V any](props map[string]interface{}, prop string) (map[K]V, error) {
+func GetMap[K comparable, V any](props map[string]interface{}, prop string, opts ...GetOptions) (map[K]V, error) {
 	if props == nil {
 		return nil, &MissingFieldError{Prop: prop}
 	}
@@ -73,7 +71,7 @@ func GetMap[K comparable, V any](props map[string]interface{}, prop string) (map
 		return nil, &MissingFieldError{Prop: prop}
 	}
 	if castVal, ok := val.(map[K]V); ok {
-		return castVal, nil
+		return copyResult(castVal, opts), nil
 	}
 	// Special case for map[string]interface{}
 	// Since we can't easily iterate and cast generic K, V without reflection.
@@ -81,24 +79,91 @@ func GetMap[K comparable, V any](props map[string]interface{}, prop string) (map
 }
 
 // MustGetMap retrieves a map property or panics.
-func MustGetMap[K comparable, V any](props map[string]interface{}, prop string) map[K]V {
-	val, err := GetMap[K, V](props, prop)
+func MustGetMap[K comparable, V any](props map[string]interface{}, prop string, opts ...GetOptions) map[K]V {
+	val, err := GetMap[K, V](props, prop, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetObjectWith retrieves an object property and builds T from it using constructorFunc.
+// Errors returned by constructorFunc are wrapped in a *ConstructorError.
+func GetObjectWith[T any](props map[string]interface{}, prop string, constructorFunc func(map[string]interface{}) (T, error)) (T, error) {
+	var zero T
+	if props == nil {
+		return zero, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return zero, &MissingFieldError{Prop: prop}
+	}
+	m, ok := val.(map[string]interface{})
+	if !ok {
+		return zero, &InvalidTypeError{Prop: prop, Expected: "object", Actual: val}
+	}
+	res, err := constructorFunc(m)
+	if err != nil {
+		return zero, &ConstructorError{Prop: prop, Index: -1, Cause: err}
+	}
+	return res, nil
+}
+
+// MustGetObjectWith retrieves an object property built by constructorFunc or panics.
+func MustGetObjectWith[T any](props map[string]interface{}, prop string, constructorFunc func(map[string]interface{}) (T, error)) T {
+	val, err := GetObjectWith(props, prop, constructorFunc)
 	if err != nil {
 		panic(err)
 	}
 	return val
 }
 
+// GetObjectWithOrDefault retrieves an object property built by constructorFunc or returns a default value.
+func GetObjectWithOrDefault[T any](props map[string]interface{}, prop string, constructorFunc func(map[string]interface{}) (T, error), defaultValue T) T {
+	val, err := GetObjectWith(props, prop, constructorFunc)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetObjectWithPtr retrieves an object property built by constructorFunc as a pointer.
+func GetObjectWithPtr[T any](props map[string]interface{}, prop string, constructorFunc func(map[string]interface{}) (T, error)) (*T, error) {
+	val, err := GetObjectWith(props, prop, constructorFunc)
+	if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// MustGetObjectWithPtr retrieves an object property built by constructorFunc as a pointer or panics.
+func MustGetObjectWithPtr[T any](props map[string]interface{}, prop string, constructorFunc func(map[string]interface{}) (T, error)) *T {
+	val, err := GetObjectWithPtr(props, prop, constructorFunc)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetObjectWithPtrOrDefault retrieves an object property built by constructorFunc as a pointer or returns a default value.
+func GetObjectWithPtrOrDefault[T any](props map[string]interface{}, prop string, constructorFunc func(map[string]interface{}) (T, error), defaultValue *T) *T {
+	val, err := GetObjectWithPtr(props, prop, constructorFunc)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
 // Legacy Aliases
 
 // GetObjectPropOrDefault
-func GetObjectPropOrDefault[T any](props map[string]interface{}, prop string, defaultValue T) T {
-	return GetObjectOrDefault(props, prop, defaultValue)
+func GetObjectPropOrDefault[T any](props map[string]interface{}, prop string, defaultValue T, opts ...GetOptions) T {
+	return GetObjectOrDefault(props, prop, defaultValue, opts...)
 }
 
 // GetMapPropOrDefault
-func GetMapPropOrDefault[K comparable, V any](props map[string]interface{}, prop string, defaultValue map[K]V) map[K]V {
-	val, err := GetMap[K, V](props, prop)
+func GetMapPropOrDefault[K comparable, V any](props map[string]interface{}, prop string, defaultValue map[K]V, opts ...GetOptions) map[K]V {
+	val, err := GetMap[K, V](props, prop, opts...)
 	if err != nil {
 		return defaultValue
 	}
@@ -153,7 +218,7 @@ func GetObjectFunctionPropOrThrow[T any](props map[string]interface{}, prop stri
 }
 
 // GetObjectPropOrDefaultAllowNull
-func GetObjectPropOrDefaultAllowNull[T any](props map[string]interface{}, prop string, defaultValue T) *T {
+func GetObjectPropOrDefaultAllowNull[T any](props map[string]interface{}, prop string, defaultValue T, opts ...GetOptions) *T {
 	if props == nil {
 		return &defaultValue
 	}
@@ -165,6 +230,7 @@ func GetObjectPropOrDefaultAllowNull[T any](props map[string]interface{}, prop s
 		return nil
 	}
 	if castVal, ok := val.(T); ok {
+		castVal = copyResult(castVal, opts)
 		return &castVal
 	}
 	return &defaultValue
@@ -0,0 +1,325 @@
+package go_objectutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// PatchOperation is one operation of an RFC 6902 JSON Patch.
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value"`
+}
+
+// MarshalJSON writes the operation as an RFC 6902 object. value is always written for add,
+// replace and test, even when null, and from is always written for move and copy, even when
+// it is the root pointer "".
+func (o PatchOperation) MarshalJSON() ([]byte, error) {
+	type plain struct {
+		Op   string `json:"op"`
+		Path string `json:"path"`
+	}
+	switch o.Op {
+	case "add", "replace", "test":
+		return json.Marshal(struct {
+			plain
+			Value interface{} `json:"value"`
+		}{plain{o.Op, o.Path}, o.Value})
+	case "move", "copy":
+		return json.Marshal(struct {
+			plain
+			From string `json:"from"`
+		}{plain{o.Op, o.Path}, o.From})
+	}
+	return json.Marshal(plain{o.Op, o.Path})
+}
+
+// Patch is an RFC 6902 JSON Patch document.
+type Patch []PatchOperation
+
+// PatchError reports the operation of a patch that could not be parsed or applied.
+type PatchError struct {
+	Index int
+	Op    string
+	Path  string
+	Cause error
+}
+
+func (e *PatchError) Error() string {
+	return fmt.Sprintf("patch operation %d (%s %s) failed: %v", e.Index, e.Op, e.Path, e.Cause)
+}
+
+func (e *PatchError) Unwrap() error {
+	return e.Cause
+}
+
+// ParsePatch converts a decoded JSON Patch document, an array of operation objects, into a Patch.
+func ParsePatch(val interface{}) (Patch, error) {
+	arr, ok := toInterfaceSlice(val)
+	if !ok {
+		return nil, &InvalidTypeError{Prop: "", Expected: "array", Actual: val}
+	}
+	res := make(Patch, len(arr))
+	for i, v := range arr {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, &ElementError{Prop: "", Indices: []int{i}, Expected: "object", Actual: v}
+		}
+		op, err := GetString(m, "op")
+		if err != nil {
+			return nil, &PatchError{Index: i, Cause: err}
+		}
+		path, err := GetString(m, "path")
+		if err != nil {
+			return nil, &PatchError{Index: i, Op: op, Cause: err}
+		}
+		res[i] = PatchOperation{Op: op, Path: path}
+		switch op {
+		case "add", "replace", "test":
+			value, ok := m["value"]
+			if !ok {
+				return nil, &PatchError{Index: i, Op: op, Path: path, Cause: &MissingFieldError{Prop: "value"}}
+			}
+			res[i].Value = value
+		case "move", "copy":
+			if res[i].From, err = GetString(m, "from"); err != nil {
+				return nil, &PatchError{Index: i, Op: op, Path: path, Cause: err}
+			}
+		case "remove":
+		default:
+			return nil, &PatchError{Index: i, Op: op, Path: path, Cause: &UnknownVariantError{Prop: "op", Value: op, Accepted: []string{"add", "copy", "move", "remove", "replace", "test"}}}
+		}
+	}
+	return res, nil
+}
+
+// GetPatch retrieves a JSON Patch document property.
+func GetPatch(props map[string]interface{}, prop string) (Patch, error) {
+	if props == nil {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	if p, ok := val.(Patch); ok {
+		return p, nil
+	}
+	p, err := ParsePatch(val)
+	if err != nil {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "JSON patch", Actual: val, Cause: err}
+	}
+	return p, nil
+}
+
+// MustGetPatch retrieves a JSON Patch property or panics.
+func MustGetPatch(props map[string]interface{}, prop string) Patch {
+	val, err := GetPatch(props, prop)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetPatchOrDefault retrieves a JSON Patch property or returns a default value.
+func GetPatchOrDefault(props map[string]interface{}, prop string, defaultValue Patch) Patch {
+	val, err := GetPatch(props, prop)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// Apply applies patch to a copy of doc and returns the result. Operations are applied in order
+// and doc is never modified, so a failing patch leaves the caller's document untouched.
+// Failures are reported as a *PatchError wrapping the underlying cause.
+func Apply(doc map[string]interface{}, patch Patch) (map[string]interface{}, error) {
+	var cur interface{} = copyValue(doc)
+	for i, op := range patch {
+		next, err := applyOperation(cur, op)
+		if err != nil {
+			return nil, &PatchError{Index: i, Op: op.Op, Path: op.Path, Cause: err}
+		}
+		cur = next
+	}
+	res, ok := cur.(map[string]interface{})
+	if !ok {
+		return nil, &InvalidTypeError{Prop: "", Expected: "object", Actual: cur}
+	}
+	return res, nil
+}
+
+// applyOperation applies one operation to doc and returns the new document root.
+func applyOperation(doc interface{}, op PatchOperation) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch op.Op {
+	case "add":
+		return addPointer(doc, path, copyValue(op.Value))
+	case "remove":
+		return removePointer(doc, path)
+	case "replace":
+		if _, err := getPointer(doc, path); err != nil {
+			return nil, err
+		}
+		if len(path) == 0 {
+			return copyValue(op.Value), nil
+		}
+		return editPointer(doc, path, 0, func(parent interface{}, prop string) (interface{}, error) {
+			if m, ok := parent.(map[string]interface{}); ok {
+				m[path[len(path)-1]] = copyValue(op.Value)
+				return m, nil
+			}
+			arr, _ := toInterfaceSlice(parent)
+			idx, _ := pointerIndex(prop, path[len(path)-1], len(arr), false)
+			arr[idx] = copyValue(op.Value)
+			return arr, nil
+		})
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		val, err := getPointer(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "copy" {
+			return addPointer(doc, path, copyValue(val))
+		}
+		if op.From == op.Path {
+			return doc, nil
+		}
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, &ValidationError{Prop: op.Path, Value: val, Reason: fmt.Sprintf("cannot move '%s' into itself", op.From)}
+		}
+		if doc, err = removePointer(doc, from); err != nil {
+			return nil, err
+		}
+		return addPointer(doc, path, val)
+	case "test":
+		val, err := getPointer(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !valuesEqual(val, op.Value) {
+			return nil, &ValidationError{Prop: op.Path, Value: val, Reason: fmt.Sprintf("does not equal %v", op.Value)}
+		}
+		return doc, nil
+	}
+	return nil, &UnknownVariantError{Prop: "op", Value: op.Op, Accepted: []string{"add", "copy", "move", "remove", "replace", "test"}}
+}
+
+// getPointer returns the value at path within doc.
+func getPointer(doc interface{}, path []string) (interface{}, error) {
+	cur := doc
+	for i, token := range path {
+		prop := formatPointer(path[:i+1])
+		if m, ok := cur.(map[string]interface{}); ok {
+			if cur, ok = m[token]; !ok {
+				return nil, &MissingFieldError{Prop: prop}
+			}
+			continue
+		}
+		arr, ok := toInterfaceSlice(cur)
+		if !ok {
+			return nil, &InvalidTypeError{Prop: formatPointer(path[:i]), Expected: "object or array", Actual: cur}
+		}
+		idx, err := pointerIndex(formatPointer(path[:i]), token, len(arr), false)
+		if err != nil {
+			return nil, err
+		}
+		cur = arr[idx]
+	}
+	return cur, nil
+}
+
+// editPointer walks to the parent of the last token of path and replaces it with the result of
+// edit, returning the new container for doc. Intermediate values must already exist.
+func editPointer(container interface{}, path []string, i int, edit func(parent interface{}, prop string) (interface{}, error)) (interface{}, error) {
+	prop := formatPointer(path[:i])
+	if i == len(path)-1 {
+		if _, ok := container.(map[string]interface{}); !ok {
+			if _, ok := toInterfaceSlice(container); !ok {
+				return nil, &InvalidTypeError{Prop: prop, Expected: "object or array", Actual: container}
+			}
+		}
+		return edit(container, prop)
+	}
+	if m, ok := container.(map[string]interface{}); ok {
+		child, ok := m[path[i]]
+		if !ok {
+			return nil, &MissingFieldError{Prop: formatPointer(path[:i+1])}
+		}
+		child, err := editPointer(child, path, i+1, edit)
+		if err != nil {
+			return nil, err
+		}
+		m[path[i]] = child
+		return m, nil
+	}
+	arr, ok := toInterfaceSlice(container)
+	if !ok {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "object or array", Actual: container}
+	}
+	idx, err := pointerIndex(prop, path[i], len(arr), false)
+	if err != nil {
+		return nil, err
+	}
+	child, err := editPointer(arr[idx], path, i+1, edit)
+	if err != nil {
+		return nil, err
+	}
+	arr[idx] = child
+	return arr, nil
+}
+
+// addPointer adds value at path, inserting into arrays and setting object members.
+func addPointer(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	last := path[len(path)-1]
+	return editPointer(doc, path, 0, func(parent interface{}, prop string) (interface{}, error) {
+		if m, ok := parent.(map[string]interface{}); ok {
+			m[last] = value
+			return m, nil
+		}
+		arr, _ := toInterfaceSlice(parent)
+		idx, err := pointerIndex(prop, last, len(arr), true)
+		if err != nil {
+			return nil, err
+		}
+		res := make([]interface{}, 0, len(arr)+1)
+		res = append(append(append(res, arr[:idx]...), value), arr[idx:]...)
+		return res, nil
+	})
+}
+
+// removePointer removes the value at path, shifting later array elements down.
+func removePointer(doc interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, &ValidationError{Prop: "", Value: doc, Reason: "cannot remove the document root"}
+	}
+	last := path[len(path)-1]
+	return editPointer(doc, path, 0, func(parent interface{}, prop string) (interface{}, error) {
+		if m, ok := parent.(map[string]interface{}); ok {
+			if _, ok := m[last]; !ok {
+				return nil, &MissingFieldError{Prop: formatPointer(path)}
+			}
+			delete(m, last)
+			return m, nil
+		}
+		arr, _ := toInterfaceSlice(parent)
+		idx, err := pointerIndex(prop, last, len(arr), false)
+		if err != nil {
+			return nil, err
+		}
+		return append(arr[:idx:idx], arr[idx+1:]...), nil
+	})
+}
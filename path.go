@@ -0,0 +1,136 @@
+package go_objectutils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pathSegment is one step of a path: a map key, an array index, or an append marker.
+type pathSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	isAppend bool
+}
+
+// parsePath parses a path such as "users[0].name" or "tags[]".
+// Keys are separated by dots and array indices are written in brackets; "[]" or "[-]"
+// refers to the position after the last element. A backslash escapes ".", "[" or "\" inside keys.
+func parsePath(path string) ([]pathSegment, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty path")
+	}
+	var segs []pathSegment
+	var key strings.Builder
+	// pending records whether a key is being accumulated and must be flushed.
+	pending := true
+	flush := func() error {
+		if !pending {
+			return nil
+		}
+		if key.Len() == 0 {
+			return fmt.Errorf("path '%s' has an empty key", path)
+		}
+		segs = append(segs, pathSegment{key: key.String()})
+		key.Reset()
+		pending = false
+		return nil
+	}
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch c {
+		case '\\':
+			if i+1 >= len(path) {
+				return nil, fmt.Errorf("path '%s' ends with an escape", path)
+			}
+			i++
+			key.WriteByte(path[i])
+			pending = true
+		case '.':
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			pending = true
+		case '[':
+			if pending && key.Len() > 0 {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+			}
+			pending = false
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("path '%s' has an unclosed '['", path)
+			}
+			text := path[i+1 : i+end]
+			i += end
+			if text == "" || text == "-" {
+				segs = append(segs, pathSegment{isIndex: true, isAppend: true})
+				continue
+			}
+			n, err := strconv.Atoi(text)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("path '%s' has an invalid index '%s'", path, text)
+			}
+			segs = append(segs, pathSegment{isIndex: true, index: n})
+		default:
+			key.WriteByte(c)
+			pending = true
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return segs, nil
+}
+
+// formatPath renders segments back into path syntax for error messages.
+func formatPath(segs []pathSegment) string {
+	var b strings.Builder
+	for i, s := range segs {
+		switch {
+		case s.isAppend:
+			b.WriteString("[]")
+		case s.isIndex:
+			fmt.Fprintf(&b, "[%d]", s.index)
+		default:
+			if i > 0 {
+				b.WriteByte('.')
+			}
+			for j := 0; j < len(s.key); j++ {
+				if c := s.key[j]; c == '.' || c == '[' || c == '\\' {
+					b.WriteByte('\\')
+				}
+				b.WriteByte(s.key[j])
+			}
+		}
+	}
+	return b.String()
+}
+
+// lookupPath returns the value at segs below doc and whether it exists.
+func lookupPath(doc interface{}, segs []pathSegment) (interface{}, bool) {
+	cur := doc
+	for _, seg := range segs {
+		if seg.isAppend {
+			return nil, false
+		}
+		if seg.isIndex {
+			arr, ok := toInterfaceSlice(cur)
+			if !ok || seg.index >= len(arr) {
+				return nil, false
+			}
+			cur = arr[seg.index]
+			continue
+		}
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = m[seg.key]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
@@ -0,0 +1,150 @@
+package go_objectutils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PathMode controls how path operations treat a missing source path.
+type PathMode int
+
+const (
+	// IgnoreMissing reports a missing source as no change.
+	IgnoreMissing PathMode = iota
+	// RequireSource returns a *MissingFieldError when the source path does not exist.
+	RequireSource
+)
+
+// missingSource returns the error for a missing path according to mode.
+func missingSource(path string, mode []PathMode) error {
+	for _, m := range mode {
+		if m == RequireSource {
+			return &MissingFieldError{Prop: path}
+		}
+	}
+	return nil
+}
+
+// DeletePath removes the value at path, shifting later elements down when it is an array element.
+// It reports whether anything was removed.
+func DeletePath(doc map[string]interface{}, path string, mode ...PathMode) (bool, error) {
+	segs, err := parsePath(path)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := lookupPath(doc, segs); !ok {
+		return false, missingSource(path, mode)
+	}
+	deleteSegments(doc, segs, 0)
+	return true, nil
+}
+
+// deleteSegments removes segs[i:] below container, which must exist, and returns the container
+// to store in its parent.
+func deleteSegments(container interface{}, segs []pathSegment, i int) interface{} {
+	seg := segs[i]
+	last := i == len(segs)-1
+	if seg.isIndex {
+		arr, _ := toInterfaceSlice(container)
+		if last {
+			return append(arr[:seg.index:seg.index], arr[seg.index+1:]...)
+		}
+		arr[seg.index] = deleteSegments(arr[seg.index], segs, i+1)
+		return arr
+	}
+	m := container.(map[string]interface{})
+	if last {
+		delete(m, seg.key)
+	} else {
+		m[seg.key] = deleteSegments(m[seg.key], segs, i+1)
+	}
+	return m
+}
+
+// RenamePath renames the key at path to newName within the same object, replacing any value
+// already under newName. It reports whether anything changed.
+func RenamePath(doc map[string]interface{}, path string, newName string, mode ...PathMode) (bool, error) {
+	segs, err := parsePath(path)
+	if err != nil {
+		return false, err
+	}
+	last := segs[len(segs)-1]
+	if last.isIndex {
+		return false, fmt.Errorf("path '%s' does not end in a key", path)
+	}
+	to := append(append([]pathSegment(nil), segs[:len(segs)-1]...), pathSegment{key: newName})
+	return movePath(doc, path, segs, to, mode)
+}
+
+// MovePath moves the value at from to to, with to written as by SetPath.
+// It reports whether anything changed.
+func MovePath(doc map[string]interface{}, from string, to string, mode ...PathMode) (bool, error) {
+	fromSegs, err := parsePath(from)
+	if err != nil {
+		return false, err
+	}
+	toSegs, err := parsePath(to)
+	if err != nil {
+		return false, err
+	}
+	return movePath(doc, from, fromSegs, toSegs, mode)
+}
+
+func movePath(doc map[string]interface{}, from string, fromSegs, toSegs []pathSegment, mode []PathMode) (bool, error) {
+	val, ok := lookupPath(doc, fromSegs)
+	if !ok {
+		return false, missingSource(from, mode)
+	}
+	fromPath, toPath := formatPath(fromSegs), formatPath(toSegs)
+	if fromPath == toPath {
+		return false, nil
+	}
+	if strings.HasPrefix(toPath, fromPath+".") || strings.HasPrefix(toPath, fromPath+"[") {
+		return false, &ValidationError{Prop: toPath, Value: val, Reason: fmt.Sprintf("cannot move '%s' into itself", fromPath)}
+	}
+	// Write the destination first so a failure leaves doc unchanged.
+	if err := SetPath(doc, toPath, val); err != nil {
+		return false, err
+	}
+	if sourceRemains(doc, fromSegs, toSegs) {
+		deleteSegments(doc, fromSegs, 0)
+	}
+	return true, nil
+}
+
+// sourceRemains reports whether the source still needs deleting once the destination has been
+// written. Writes replace in place or append, so existing indices never shift; the source only
+// disappears when the destination overwrote one of its ancestors.
+func sourceRemains(doc map[string]interface{}, fromSegs, toSegs []pathSegment) bool {
+	if len(toSegs) < len(fromSegs) {
+		prefix := true
+		for i, s := range toSegs {
+			if s != fromSegs[i] {
+				prefix = false
+				break
+			}
+		}
+		if prefix {
+			return false
+		}
+	}
+	_, ok := lookupPath(doc, fromSegs)
+	return ok
+}
+
+// CopyPath copies the value at from to to, with to written as by SetPath. Nested maps and
+// arrays are copied so the two locations do not share state. It reports whether anything changed.
+func CopyPath(doc map[string]interface{}, from string, to string, mode ...PathMode) (bool, error) {
+	fromSegs, err := parsePath(from)
+	if err != nil {
+		return false, err
+	}
+	val, ok := lookupPath(doc, fromSegs)
+	if !ok {
+		return false, missingSource(from, mode)
+	}
+	if err := SetPath(doc, to, copyValue(val)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
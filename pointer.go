@@ -0,0 +1,61 @@
+package go_objectutils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parsePointer splits an RFC 6901 JSON Pointer such as "/a/0/b" into unescaped reference tokens.
+// The empty pointer refers to the whole document and yields no tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("JSON pointer '%s' must start with '/'", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		for j := 0; j < len(t); j++ {
+			if t[j] == '~' && (j+1 >= len(t) || (t[j+1] != '0' && t[j+1] != '1')) {
+				return nil, fmt.Errorf("JSON pointer '%s' has an invalid escape", pointer)
+			}
+		}
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+	}
+	return tokens, nil
+}
+
+// formatPointer joins reference tokens into an RFC 6901 JSON Pointer.
+func formatPointer(tokens []string) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteByte('/')
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(t))
+	}
+	return b.String()
+}
+
+// pointerIndex converts a reference token into an array index. "-" refers to the position after
+// the last element and is returned as length when allowEnd is set.
+func pointerIndex(prop string, token string, length int, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return length, nil
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.Trim(token, "0123456789") != "" {
+		return 0, &InvalidTypeError{Prop: prop, Expected: "array index", Actual: token}
+	}
+	n, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, &InvalidTypeError{Prop: prop, Expected: "array index", Actual: token, Cause: err}
+	}
+	max := length - 1
+	if allowEnd {
+		max = length
+	}
+	if n > max {
+		return 0, &IndexError{Prop: prop, Index: n, Length: length}
+	}
+	return n, nil
+}
@@ -0,0 +1,297 @@
+package go_objectutils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	expression string
+	second     uint64
+	minute     uint64
+	hour       uint64
+	dom        uint64
+	month      uint64
+	dow        uint64
+	// domStar and dowStar record unrestricted day fields; when both day fields are
+	// restricted a day matches if either does, as in standard cron.
+	domStar bool
+	dowStar bool
+}
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	cronSecond = cronField{name: "second", min: 0, max: 59}
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day-of-month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	cronDow = cronField{name: "day-of-week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseSchedule parses a cron expression. It accepts the standard five fields
+// (minute, hour, day of month, month, day of week), six fields with a leading seconds
+// field, and the descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly.
+// Fields support "*", "?", lists, ranges, steps and month or weekday names.
+func ParseSchedule(expression string) (*Schedule, error) {
+	spec := strings.TrimSpace(expression)
+	if strings.HasPrefix(spec, "@") {
+		d, ok := cronDescriptors[strings.ToLower(spec)]
+		if !ok {
+			return nil, &CronFieldError{Expression: expression, Field: "descriptor", Value: spec, Reason: "unknown descriptor"}
+		}
+		spec = d
+	}
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("cron expression '%s' has %d fields, expected 5 or 6", expression, len(fields))
+	}
+	s := &Schedule{expression: expression}
+	var err error
+	for i, f := range []struct {
+		field cronField
+		dst   *uint64
+	}{
+		{cronSecond, &s.second},
+		{cronMinute, &s.minute},
+		{cronHour, &s.hour},
+		{cronDom, &s.dom},
+		{cronMonth, &s.month},
+		{cronDow, &s.dow},
+	} {
+		if *f.dst, err = f.field.parse(expression, fields[i]); err != nil {
+			return nil, err
+		}
+	}
+	// Sunday may be written as 7.
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domStar = fields[3] == "*" || fields[3] == "?"
+	s.dowStar = fields[5] == "*" || fields[5] == "?"
+	return s, nil
+}
+
+// parse converts a single cron field into a bit set of allowed values.
+func (f cronField) parse(expression, text string) (uint64, error) {
+	fail := func(reason string, args ...interface{}) (uint64, error) {
+		return 0, &CronFieldError{Expression: expression, Field: f.name, Value: text, Reason: fmt.Sprintf(reason, args...)}
+	}
+	var bits uint64
+	for _, part := range strings.Split(text, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return fail("invalid step '%s'", stepPart)
+			}
+			step = n
+		}
+		var lo, hi int
+		switch {
+		case rangePart == "*" || rangePart == "?":
+			if rangePart == "?" && f.name != cronDom.name && f.name != cronDow.name {
+				return fail("'?' is only allowed in day fields")
+			}
+			lo, hi = f.min, f.max
+		case strings.Contains(rangePart, "-"):
+			a, b, _ := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = f.value(a); err != nil {
+				return fail("%v", err)
+			}
+			if hi, err = f.value(b); err != nil {
+				return fail("%v", err)
+			}
+			if lo > hi {
+				return fail("range start %d is after end %d", lo, hi)
+			}
+		default:
+			var err error
+			if lo, err = f.value(rangePart); err != nil {
+				return fail("%v", err)
+			}
+			hi = lo
+			if hasStep {
+				hi = f.max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single number or name within the field's range.
+func (f cronField) value(s string) (int, error) {
+	if n, ok := f.names[strings.ToUpper(s)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not a number", s)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("value %d is out of range %d-%d", n, f.min, f.max)
+	}
+	return n, nil
+}
+
+// String returns the original expression.
+func (s *Schedule) String() string {
+	return s.expression
+}
+
+// dayMatches reports whether the date of t satisfies the day-of-month and day-of-week fields.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// Next returns the first time after t that matches the schedule, in t's location.
+// It returns the zero time if no match exists within five years, e.g. for "0 0 30 2 *".
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Add(time.Second - time.Duration(t.Nanosecond()))
+	yearLimit := t.Year() + 5
+	added := false
+
+wrap:
+	if t.Year() > yearLimit {
+		return time.Time{}
+	}
+	for s.month&(1<<uint(t.Month())) == 0 {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+		}
+		t = t.AddDate(0, 1, 0)
+		if t.Month() == time.January {
+			goto wrap
+		}
+	}
+	for !s.dayMatches(t) {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		}
+		t = t.AddDate(0, 0, 1)
+		if t.Day() == 1 {
+			goto wrap
+		}
+	}
+	for s.hour&(1<<uint(t.Hour())) == 0 {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
+		}
+		t = t.Add(time.Hour)
+		if t.Hour() == 0 {
+			goto wrap
+		}
+	}
+	for s.minute&(1<<uint(t.Minute())) == 0 {
+		if !added {
+			added = true
+			t = t.Truncate(time.Minute)
+		}
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto wrap
+		}
+	}
+	for s.second&(1<<uint(t.Second())) == 0 {
+		if !added {
+			added = true
+			t = t.Truncate(time.Second)
+		}
+		t = t.Add(time.Second)
+		if t.Second() == 0 {
+			goto wrap
+		}
+	}
+	return t
+}
+
+// convertToSchedule converts a *Schedule or cron expression string to a schedule.
+func convertToSchedule(val interface{}) (*Schedule, error) {
+	switch v := val.(type) {
+	case *Schedule:
+		if v == nil {
+			return nil, fmt.Errorf("nil schedule")
+		}
+		return v, nil
+	case string:
+		return ParseSchedule(v)
+	}
+	return nil, fmt.Errorf("cannot convert %T to schedule", val)
+}
+
+// GetSchedule retrieves a cron expression property as a Schedule.
+// Invalid expressions return an *InvalidTypeError whose cause is a *CronFieldError naming the bad field.
+func GetSchedule(props map[string]interface{}, prop string) (*Schedule, error) {
+	if props == nil {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	if s, err := convertToSchedule(val); err == nil {
+		return s, nil
+	} else {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "cron schedule", Actual: val, Cause: err}
+	}
+}
+
+// MustGetSchedule retrieves a cron schedule property or panics.
+func MustGetSchedule(props map[string]interface{}, prop string) *Schedule {
+	val, err := GetSchedule(props, prop)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetScheduleOrDefault retrieves a cron schedule property or returns a default value.
+func GetScheduleOrDefault(props map[string]interface{}, prop string, defaultValue *Schedule) *Schedule {
+	val, err := GetSchedule(props, prop)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
@@ -0,0 +1,134 @@
+package go_objectutils
+
+import "reflect"
+
+// SetMode controls how set accessors treat duplicate elements.
+type SetMode int
+
+const (
+	// MergeDuplicates silently collapses repeated elements into one set member.
+	MergeDuplicates SetMode = iota
+	// RejectDuplicates returns a *DuplicateElementError when an element repeats.
+	RejectDuplicates
+)
+
+// toSet builds a set from values according to mode.
+func toSet[T comparable](prop string, values []T, mode ...SetMode) (map[T]struct{}, error) {
+	reject := false
+	for _, m := range mode {
+		reject = m == RejectDuplicates
+	}
+	res := make(map[T]struct{}, len(values))
+	for i, v := range values {
+		if _, ok := res[v]; ok && reject {
+			for j := range values[:i] {
+				if values[j] == v {
+					return nil, &DuplicateElementError{Prop: prop, Value: v, FirstIndex: j, SecondIndex: i}
+				}
+			}
+		}
+		res[v] = struct{}{}
+	}
+	return res, nil
+}
+
+// GetSet retrieves an array property as a set, converting each element with the scalar rules.
+func GetSet[T comparable](props map[string]interface{}, prop string, mode ...SetMode) (map[T]struct{}, error) {
+	if props == nil {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	arr, ok := toInterfaceSlice(val)
+	if !ok {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
+	}
+	values := make([]T, len(arr))
+	for i, v := range arr {
+		converted, err := convertValue[T](v)
+		if err != nil {
+			return nil, &ElementError{Prop: prop, Indices: []int{i}, Expected: typeName[T](), Actual: v, Cause: err}
+		}
+		// T may be an interface type, in which case objects and arrays convert successfully
+		// but cannot be used as map keys.
+		if rt := reflect.TypeOf(any(converted)); rt != nil && !rt.Comparable() {
+			return nil, &ElementError{Prop: prop, Indices: []int{i}, Expected: "comparable value", Actual: v}
+		}
+		values[i] = converted
+	}
+	return toSet(prop, values, mode...)
+}
+
+// MustGetSet retrieves a set property or panics.
+func MustGetSet[T comparable](props map[string]interface{}, prop string, mode ...SetMode) map[T]struct{} {
+	val, err := GetSet[T](props, prop, mode...)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetSetOrDefault retrieves a set property or returns a default value.
+func GetSetOrDefault[T comparable](props map[string]interface{}, prop string, defaultValue map[T]struct{}, mode ...SetMode) map[T]struct{} {
+	val, err := GetSet[T](props, prop, mode...)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetStringSet retrieves a string array property as a set.
+func GetStringSet(props map[string]interface{}, prop string, mode ...SetMode) (map[string]struct{}, error) {
+	arr, err := GetStringArray(props, prop)
+	if err != nil {
+		return nil, err
+	}
+	return toSet(prop, arr, mode...)
+}
+
+// MustGetStringSet retrieves a string set property or panics.
+func MustGetStringSet(props map[string]interface{}, prop string, mode ...SetMode) map[string]struct{} {
+	val, err := GetStringSet(props, prop, mode...)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetStringSetOrDefault retrieves a string set property or returns a default value.
+func GetStringSetOrDefault(props map[string]interface{}, prop string, defaultValue map[string]struct{}, mode ...SetMode) map[string]struct{} {
+	val, err := GetStringSet(props, prop, mode...)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetNumberSet retrieves a number array property as a set.
+func GetNumberSet[T NumberConstraint](props map[string]interface{}, prop string, mode ...SetMode) (map[T]struct{}, error) {
+	arr, err := GetNumberArray[T](props, prop)
+	if err != nil {
+		return nil, err
+	}
+	return toSet(prop, arr, mode...)
+}
+
+// MustGetNumberSet retrieves a number set property or panics.
+func MustGetNumberSet[T NumberConstraint](props map[string]interface{}, prop string, mode ...SetMode) map[T]struct{} {
+	val, err := GetNumberSet[T](props, prop, mode...)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetNumberSetOrDefault retrieves a number set property or returns a default value.
+func GetNumberSetOrDefault[T NumberConstraint](props map[string]interface{}, prop string, defaultValue map[T]struct{}, mode ...SetMode) map[T]struct{} {
+	val, err := GetNumberSet[T](props, prop, mode...)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
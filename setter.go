@@ -0,0 +1,105 @@
+package go_objectutils
+
+import (
+	"time"
+)
+
+// SetPath writes value at path within doc, creating missing intermediate maps and arrays.
+// Paths use dots for keys and brackets for array indices, e.g. "users[0].name"; "[]" appends.
+// An index may be at most the current array length. If an intermediate value exists with an
+// incompatible type an *InvalidTypeError is returned and doc is left unchanged.
+func SetPath(doc map[string]interface{}, path string, value interface{}) error {
+	if doc == nil {
+		return &InvalidTypeError{Prop: path, Expected: "object", Actual: doc}
+	}
+	segs, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	if segs[0].isIndex {
+		return &InvalidTypeError{Prop: "", Expected: "array", Actual: doc}
+	}
+	_, err = setSegments(doc, segs, 0, value)
+	return err
+}
+
+// setSegments writes value at segs[i:] below container and returns the container to store
+// in its parent, which differs from container when it was created or an array grew.
+func setSegments(container interface{}, segs []pathSegment, i int, value interface{}) (interface{}, error) {
+	seg := segs[i]
+	last := i == len(segs)-1
+	if seg.isIndex {
+		arr, ok := container.([]interface{})
+		if !ok && container != nil {
+			if arr, ok = toInterfaceSlice(container); !ok {
+				return nil, &InvalidTypeError{Prop: formatPath(segs[:i]), Expected: "array", Actual: container}
+			}
+		}
+		idx := seg.index
+		if seg.isAppend {
+			idx = len(arr)
+		}
+		if idx > len(arr) {
+			return nil, &IndexError{Prop: formatPath(segs[:i]), Index: idx, Length: len(arr)}
+		}
+		var child interface{}
+		if idx < len(arr) {
+			child = arr[idx]
+		}
+		if !last {
+			var err error
+			if child, err = setSegments(child, segs, i+1, value); err != nil {
+				return nil, err
+			}
+		} else {
+			child = value
+		}
+		if idx == len(arr) {
+			return append(arr, child), nil
+		}
+		arr[idx] = child
+		return arr, nil
+	}
+	m, ok := container.(map[string]interface{})
+	if !ok {
+		if container != nil {
+			return nil, &InvalidTypeError{Prop: formatPath(segs[:i]), Expected: "object", Actual: container}
+		}
+		m = map[string]interface{}{}
+	}
+	if last {
+		m[seg.key] = value
+		return m, nil
+	}
+	child, err := setSegments(m[seg.key], segs, i+1, value)
+	if err != nil {
+		return nil, err
+	}
+	m[seg.key] = child
+	return m, nil
+}
+
+// SetString writes a string at path.
+func SetString(doc map[string]interface{}, path string, value string) error {
+	return SetPath(doc, path, value)
+}
+
+// SetNumber writes a number at path.
+func SetNumber[T NumberConstraint](doc map[string]interface{}, path string, value T) error {
+	return SetPath(doc, path, value)
+}
+
+// SetBoolean writes a boolean at path.
+func SetBoolean(doc map[string]interface{}, path string, value bool) error {
+	return SetPath(doc, path, value)
+}
+
+// SetDate writes a date at path as an RFC3339 string, the form GetDate reads back.
+func SetDate(doc map[string]interface{}, path string, value time.Time) error {
+	return SetPath(doc, path, value.Format(time.RFC3339Nano))
+}
+
+// SetObject writes an object at path.
+func SetObject(doc map[string]interface{}, path string, value map[string]interface{}) error {
+	return SetPath(doc, path, value)
+}
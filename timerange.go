@@ -0,0 +1,239 @@
+package go_objectutils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeRange is a span of time from Start to End.
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the range.
+func (r TimeRange) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// Contains reports whether t is within the half-open range [Start, End).
+func (r TimeRange) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && t.Before(r.End)
+}
+
+// String returns the range as an ISO 8601 interval of two RFC3339 times.
+func (r TimeRange) String() string {
+	return r.Start.Format(time.RFC3339Nano) + "/" + r.End.Format(time.RFC3339Nano)
+}
+
+// TimeRangeOptions configures the time range accessors.
+type TimeRangeOptions struct {
+	// StartKey and EndKey name the fields of the object form. They default to "start" and "end".
+	StartKey string
+	EndKey   string
+	// AllowEmpty accepts ranges where End equals Start.
+	AllowEmpty bool
+	// MaxSpan, when greater than zero, rejects ranges longer than this.
+	MaxSpan time.Duration
+}
+
+// ISODuration is an ISO 8601 duration such as "P1Y2M10DT2H30M". Calendar components are
+// kept separate from the clock component because their length depends on the date they are applied to.
+type ISODuration struct {
+	Years  int
+	Months int
+	Days   int
+	Clock  time.Duration
+}
+
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+// ParseISODuration parses an ISO 8601 duration such as "P1M", "P2W" or "PT1H30M".
+func ParseISODuration(s string) (ISODuration, error) {
+	m := isoDurationPattern.FindStringSubmatch(s)
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return ISODuration{}, fmt.Errorf("cannot parse '%s' as an ISO 8601 duration", s)
+	}
+	atoi := func(v string) int {
+		n, _ := strconv.Atoi(v)
+		return n
+	}
+	d := ISODuration{
+		Years:  atoi(m[1]),
+		Months: atoi(m[2]),
+		Days:   atoi(m[3])*7 + atoi(m[4]),
+		Clock:  time.Duration(atoi(m[5]))*time.Hour + time.Duration(atoi(m[6]))*time.Minute,
+	}
+	if m[7] != "" {
+		secs, err := strconv.ParseFloat(strings.Replace(m[7], ",", ".", 1), 64)
+		if err != nil {
+			return ISODuration{}, err
+		}
+		d.Clock += time.Duration(secs * float64(time.Second))
+	}
+	return d, nil
+}
+
+// AddTo returns t advanced by d.
+func (d ISODuration) AddTo(t time.Time) time.Time {
+	return t.AddDate(d.Years, d.Months, d.Days).Add(d.Clock)
+}
+
+// SubtractFrom returns t moved back by d.
+func (d ISODuration) SubtractFrom(t time.Time) time.Time {
+	return t.AddDate(-d.Years, -d.Months, -d.Days).Add(-d.Clock)
+}
+
+// parseIntervalTime parses one end of a time range, accepting the values parseDate accepts and
+// ISO 8601 calendar date strings (taken as midnight UTC).
+func parseIntervalTime(val interface{}) (time.Time, error) {
+	t, err := parseDate(val)
+	if err == nil {
+		return t, nil
+	}
+	s, ok := val.(string)
+	if !ok {
+		return time.Time{}, err
+	}
+	d, err := ParseCivilDate(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return d.In(time.UTC), nil
+}
+
+// getIntervalTime retrieves one end of a time range given as an object field.
+func getIntervalTime(props map[string]interface{}, prop string) (time.Time, error) {
+	val, ok := props[prop]
+	if !ok {
+		return time.Time{}, &MissingFieldError{Prop: prop}
+	}
+	t, err := parseIntervalTime(val)
+	if err != nil {
+		return time.Time{}, &InvalidTypeError{Prop: prop, Expected: "time.Time", Actual: val, Cause: err}
+	}
+	return t, nil
+}
+
+// ParseInterval parses an ISO 8601 interval in the forms "start/end", "start/duration" or "duration/end".
+func ParseInterval(s string) (TimeRange, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return TimeRange{}, fmt.Errorf("cannot parse '%s' as an ISO 8601 interval", s)
+	}
+	switch {
+	case strings.HasPrefix(parts[0], "P") && strings.HasPrefix(parts[1], "P"):
+		return TimeRange{}, fmt.Errorf("interval '%s' needs at least one time", s)
+	case strings.HasPrefix(parts[1], "P"):
+		start, err := parseIntervalTime(parts[0])
+		if err != nil {
+			return TimeRange{}, err
+		}
+		d, err := ParseISODuration(parts[1])
+		if err != nil {
+			return TimeRange{}, err
+		}
+		return TimeRange{Start: start, End: d.AddTo(start)}, nil
+	case strings.HasPrefix(parts[0], "P"):
+		d, err := ParseISODuration(parts[0])
+		if err != nil {
+			return TimeRange{}, err
+		}
+		end, err := parseIntervalTime(parts[1])
+		if err != nil {
+			return TimeRange{}, err
+		}
+		return TimeRange{Start: d.SubtractFrom(end), End: end}, nil
+	}
+	start, err := parseIntervalTime(parts[0])
+	if err != nil {
+		return TimeRange{}, err
+	}
+	end, err := parseIntervalTime(parts[1])
+	if err != nil {
+		return TimeRange{}, err
+	}
+	return TimeRange{Start: start, End: end}, nil
+}
+
+// GetTimeRange retrieves a time range property given either as an object with start and end
+// fields or as an ISO 8601 interval string, and checks that it ends after it starts.
+func GetTimeRange(props map[string]interface{}, prop string, opts ...TimeRangeOptions) (TimeRange, error) {
+	var opt TimeRangeOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.StartKey == "" {
+		opt.StartKey = "start"
+	}
+	if opt.EndKey == "" {
+		opt.EndKey = "end"
+	}
+	if props == nil {
+		return TimeRange{}, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return TimeRange{}, &MissingFieldError{Prop: prop}
+	}
+	var r TimeRange
+	switch v := val.(type) {
+	case TimeRange:
+		r = v
+	case string:
+		parsed, err := ParseInterval(v)
+		if err != nil {
+			return TimeRange{}, &InvalidTypeError{Prop: prop, Expected: "time range", Actual: val, Cause: err}
+		}
+		r = parsed
+	case map[string]interface{}:
+		start, err := getIntervalTime(v, opt.StartKey)
+		if err != nil {
+			return TimeRange{}, &InvalidTypeError{Prop: prop, Expected: "time range", Actual: val, Cause: err}
+		}
+		end, err := getIntervalTime(v, opt.EndKey)
+		if err != nil {
+			return TimeRange{}, &InvalidTypeError{Prop: prop, Expected: "time range", Actual: val, Cause: err}
+		}
+		r = TimeRange{Start: start, End: end}
+	default:
+		return TimeRange{}, &InvalidTypeError{Prop: prop, Expected: "time range", Actual: val}
+	}
+	if r.End.Before(r.Start) || (!opt.AllowEmpty && r.End.Equal(r.Start)) {
+		return TimeRange{}, &ValidationError{Prop: prop, Value: val, Reason: "end must be after start"}
+	}
+	if opt.MaxSpan > 0 && r.Duration() > opt.MaxSpan {
+		return TimeRange{}, &ValidationError{Prop: prop, Value: val, Reason: fmt.Sprintf("span %s exceeds %s", r.Duration(), opt.MaxSpan)}
+	}
+	return r, nil
+}
+
+// MustGetTimeRange retrieves a time range property or panics.
+func MustGetTimeRange(props map[string]interface{}, prop string, opts ...TimeRangeOptions) TimeRange {
+	val, err := GetTimeRange(props, prop, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetTimeRangeOrDefault retrieves a time range property or returns a default value.
+func GetTimeRangeOrDefault(props map[string]interface{}, prop string, defaultValue TimeRange, opts ...TimeRangeOptions) TimeRange {
+	val, err := GetTimeRange(props, prop, opts...)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetTimeRangePtr retrieves a time range property as a pointer.
+func GetTimeRangePtr(props map[string]interface{}, prop string, opts ...TimeRangeOptions) (*TimeRange, error) {
+	val, err := GetTimeRange(props, prop, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
@@ -0,0 +1,163 @@
+package go_objectutils
+
+import (
+	"math/big"
+	"reflect"
+	"time"
+)
+
+// Pair is a fixed-length two element array such as [lng, lat] or ["k", 3].
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Triple is a fixed-length three element array.
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// convertValue converts val to T using the scalar rules of the matching Get* accessor.
+// Named string, bool and numeric types follow the rules of their underlying kind; other
+// types without a dedicated rule are cast directly.
+func convertValue[T any](val interface{}) (T, error) {
+	var zero T
+	switch any(zero).(type) {
+	case time.Time:
+		return convertResult[T](parseDate(val))
+	case *big.Int:
+		return convertResult[T](convertToBigInt(val))
+	}
+	var res interface{}
+	var err error
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	switch typ.Kind() {
+	case reflect.String:
+		res, err = convertToString(val)
+	case reflect.Bool:
+		res, err = convertToBool(val)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		res, err = convertToNumber[int64](val)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		res, err = convertToNumber[uint64](val)
+	case reflect.Float32, reflect.Float64:
+		res, err = convertToNumber[float64](val)
+	default:
+		return convertToObject[T](val)
+	}
+	if err != nil {
+		return zero, err
+	}
+	return reflect.ValueOf(res).Convert(typ).Interface().(T), nil
+}
+
+// convertResult returns res as T, or err.
+func convertResult[T any](res interface{}, err error) (T, error) {
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return res.(T), nil
+}
+
+// getTuple retrieves an array property and checks that it has exactly n elements.
+func getTuple(props map[string]interface{}, prop string, n int) ([]interface{}, error) {
+	if props == nil {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	arr, ok := toInterfaceSlice(val)
+	if !ok {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
+	}
+	if len(arr) != n {
+		return nil, &ShapeError{Prop: prop, Index: -1, Expected: n, Actual: len(arr)}
+	}
+	return arr, nil
+}
+
+// tupleElement converts the element at position i of a tuple.
+func tupleElement[T any](prop string, arr []interface{}, i int) (T, error) {
+	val, err := convertValue[T](arr[i])
+	if err != nil {
+		var zero T
+		return zero, &ElementError{Prop: prop, Indices: []int{i}, Expected: typeName[T](), Actual: arr[i], Cause: err}
+	}
+	return val, nil
+}
+
+// GetPair retrieves a two element array property, converting each position with the scalar rules.
+func GetPair[A, B any](props map[string]interface{}, prop string) (Pair[A, B], error) {
+	var res Pair[A, B]
+	arr, err := getTuple(props, prop, 2)
+	if err != nil {
+		return res, err
+	}
+	if res.First, err = tupleElement[A](prop, arr, 0); err != nil {
+		return Pair[A, B]{}, err
+	}
+	if res.Second, err = tupleElement[B](prop, arr, 1); err != nil {
+		return Pair[A, B]{}, err
+	}
+	return res, nil
+}
+
+// MustGetPair retrieves a two element array property or panics.
+func MustGetPair[A, B any](props map[string]interface{}, prop string) Pair[A, B] {
+	val, err := GetPair[A, B](props, prop)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetPairOrDefault retrieves a two element array property or returns a default value.
+func GetPairOrDefault[A, B any](props map[string]interface{}, prop string, defaultValue Pair[A, B]) Pair[A, B] {
+	val, err := GetPair[A, B](props, prop)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetTriple retrieves a three element array property, converting each position with the scalar rules.
+func GetTriple[A, B, C any](props map[string]interface{}, prop string) (Triple[A, B, C], error) {
+	var res Triple[A, B, C]
+	arr, err := getTuple(props, prop, 3)
+	if err != nil {
+		return res, err
+	}
+	if res.First, err = tupleElement[A](prop, arr, 0); err != nil {
+		return Triple[A, B, C]{}, err
+	}
+	if res.Second, err = tupleElement[B](prop, arr, 1); err != nil {
+		return Triple[A, B, C]{}, err
+	}
+	if res.Third, err = tupleElement[C](prop, arr, 2); err != nil {
+		return Triple[A, B, C]{}, err
+	}
+	return res, nil
+}
+
+// MustGetTriple retrieves a three element array property or panics.
+func MustGetTriple[A, B, C any](props map[string]interface{}, prop string) Triple[A, B, C] {
+	val, err := GetTriple[A, B, C](props, prop)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetTripleOrDefault retrieves a three element array property or returns a default value.
+func GetTripleOrDefault[A, B, C any](props map[string]interface{}, prop string, defaultValue Triple[A, B, C]) Triple[A, B, C] {
+	val, err := GetTriple[A, B, C](props, prop)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
@@ -0,0 +1,7 @@
+//go:build objectutils_tzdata
+
+package go_objectutils
+
+// Building with the objectutils_tzdata tag embeds the IANA time zone database so
+// GetLocation resolves zone names without relying on zone data installed on the host.
+import _ "time/tzdata"
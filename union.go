@@ -0,0 +1,144 @@
+package go_objectutils
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Union maps the values of a discriminator field (such as "type") to constructor
+// functions producing variants of T, which is usually an interface type.
+// Variants should be registered before the union is used for lookups. The zero value
+// with Discriminator set is ready to use.
+type Union[T any] struct {
+	Discriminator string
+	variants      map[string]func(map[string]interface{}) T
+}
+
+// NewUnion creates a Union keyed by the given discriminator field.
+func NewUnion[T any](discriminator string) *Union[T] {
+	return &Union[T]{Discriminator: discriminator, variants: map[string]func(map[string]interface{}) T{}}
+}
+
+// Register adds a constructor for objects whose discriminator equals value.
+// It returns the union so registrations can be chained.
+func (u *Union[T]) Register(value string, constructorFunc func(map[string]interface{}) T) *Union[T] {
+	if u.variants == nil {
+		u.variants = map[string]func(map[string]interface{}) T{}
+	}
+	u.variants[value] = constructorFunc
+	return u
+}
+
+// Variants returns the registered discriminator values in sorted order.
+func (u *Union[T]) Variants() []string {
+	res := make([]string, 0, len(u.variants))
+	for k := range u.variants {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Decode constructs the variant matching the discriminator of m.
+func (u *Union[T]) Decode(m map[string]interface{}) (T, error) {
+	return u.decode(m, u.Discriminator)
+}
+
+// decode constructs the variant for m, reporting errors against path.
+func (u *Union[T]) decode(m map[string]interface{}, path string) (T, error) {
+	var zero T
+	val, ok := m[u.Discriminator]
+	if !ok {
+		return zero, &MissingFieldError{Prop: path}
+	}
+	s, ok := val.(string)
+	if !ok {
+		return zero, &InvalidTypeError{Prop: path, Expected: "string", Actual: val}
+	}
+	constructorFunc, ok := u.variants[s]
+	if !ok {
+		return zero, &UnknownVariantError{Prop: path, Value: s, Accepted: u.Variants()}
+	}
+	return constructorFunc(m), nil
+}
+
+// GetUnion retrieves an object property and constructs the variant selected by its discriminator.
+func GetUnion[T any](props map[string]interface{}, prop string, union *Union[T]) (T, error) {
+	var zero T
+	if props == nil {
+		return zero, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return zero, &MissingFieldError{Prop: prop}
+	}
+	m, ok := val.(map[string]interface{})
+	if !ok {
+		return zero, &InvalidTypeError{Prop: prop, Expected: "object", Actual: val}
+	}
+	return union.decode(m, prop+"."+union.Discriminator)
+}
+
+// MustGetUnion retrieves a union property or panics.
+func MustGetUnion[T any](props map[string]interface{}, prop string, union *Union[T]) T {
+	val, err := GetUnion(props, prop, union)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetUnionOrDefault retrieves a union property or returns a default value.
+func GetUnionOrDefault[T any](props map[string]interface{}, prop string, union *Union[T], defaultValue T) T {
+	val, err := GetUnion(props, prop, union)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetUnionArray retrieves an array of objects, constructing each element's variant from its discriminator.
+func GetUnionArray[T any](props map[string]interface{}, prop string, union *Union[T]) ([]T, error) {
+	if props == nil {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	arr, ok := toInterfaceSlice(val)
+	if !ok {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "array", Actual: val}
+	}
+	res := make([]T, len(arr))
+	for i, v := range arr {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, &ElementError{Prop: prop, Indices: []int{i}, Expected: "object", Actual: v}
+		}
+		variant, err := union.decode(m, fmt.Sprintf("%s[%d].%s", prop, i, union.Discriminator))
+		if err != nil {
+			return nil, err
+		}
+		res[i] = variant
+	}
+	return res, nil
+}
+
+// MustGetUnionArray retrieves a union array property or panics.
+func MustGetUnionArray[T any](props map[string]interface{}, prop string, union *Union[T]) []T {
+	val, err := GetUnionArray(props, prop, union)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetUnionArrayOrDefault retrieves a union array property or returns a default value.
+func GetUnionArrayOrDefault[T any](props map[string]interface{}, prop string, union *Union[T], defaultValue []T) []T {
+	val, err := GetUnionArray(props, prop, union)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
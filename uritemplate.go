@@ -0,0 +1,175 @@
+package go_objectutils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// URITemplate is a parsed RFC 6570 level 1 or level 2 URI template, such as
+// "https://example.com/users/{id}/hooks{#section}".
+type URITemplate struct {
+	raw   string
+	parts []uriTemplatePart
+}
+
+// uriTemplatePart is either a literal or a single-variable expression.
+type uriTemplatePart struct {
+	literal  string
+	operator byte
+	name     string
+}
+
+var uriTemplateVarname = regexp.MustCompile(`^(?:[A-Za-z0-9_]|%[0-9A-Fa-f]{2})+(?:\.(?:[A-Za-z0-9_]|%[0-9A-Fa-f]{2})+)*$`)
+
+// ParseURITemplate parses a level 1 ({var}) or level 2 ({+var}, {#var}) URI template.
+func ParseURITemplate(s string) (*URITemplate, error) {
+	t := &URITemplate{raw: s}
+	rest := s
+	for rest != "" {
+		open := strings.IndexAny(rest, "{}")
+		if open < 0 {
+			t.parts = append(t.parts, uriTemplatePart{literal: rest})
+			break
+		}
+		if rest[open] == '}' {
+			return nil, fmt.Errorf("template '%s' has an unmatched '}'", s)
+		}
+		if open > 0 {
+			t.parts = append(t.parts, uriTemplatePart{literal: rest[:open]})
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("template '%s' has an unclosed expression", s)
+		}
+		expr := rest[open+1 : open+end]
+		rest = rest[open+end+1:]
+		part := uriTemplatePart{}
+		if expr != "" && (expr[0] == '+' || expr[0] == '#') {
+			part.operator = expr[0]
+			expr = expr[1:]
+		} else if expr != "" && strings.ContainsRune("./;?&=,!@|", rune(expr[0])) {
+			return nil, fmt.Errorf("template '%s' uses operator '%c' which is beyond level 2", s, expr[0])
+		}
+		if !uriTemplateVarname.MatchString(expr) {
+			return nil, fmt.Errorf("template '%s' has an invalid variable name '%s'", s, expr)
+		}
+		part.name = expr
+		t.parts = append(t.parts, part)
+	}
+	return t, nil
+}
+
+// String returns the original template.
+func (t *URITemplate) String() string {
+	return t.raw
+}
+
+// Variables returns the variable names used by the template in order of appearance.
+func (t *URITemplate) Variables() []string {
+	var res []string
+	seen := map[string]bool{}
+	for _, p := range t.parts {
+		if p.name != "" && !seen[p.name] {
+			seen[p.name] = true
+			res = append(res, p.name)
+		}
+	}
+	return res
+}
+
+// Expand substitutes variables from values. Undefined or nil variables expand to nothing,
+// as required by RFC 6570. Values must be strings, numbers or booleans.
+func (t *URITemplate) Expand(values map[string]interface{}) (string, error) {
+	var b strings.Builder
+	for _, p := range t.parts {
+		if p.name == "" {
+			b.WriteString(p.literal)
+			continue
+		}
+		val, ok := values[p.name]
+		if !ok || val == nil {
+			continue
+		}
+		var s string
+		switch v := val.(type) {
+		case string:
+			s = v
+		case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			s = fmt.Sprint(v)
+		default:
+			return "", &InvalidTypeError{Prop: p.name, Expected: "string, number or bool", Actual: val}
+		}
+		if p.operator == '#' {
+			b.WriteByte('#')
+		}
+		b.WriteString(uriTemplateEncode(s, p.operator != 0))
+	}
+	return b.String(), nil
+}
+
+// uriTemplateEncode percent-encodes s, keeping unreserved characters and, when
+// reserved is set, reserved characters and existing percent-encoded triplets.
+func uriTemplateEncode(s string, reserved bool) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', strings.IndexByte("-._~", c) >= 0:
+			b.WriteByte(c)
+		case reserved && strings.IndexByte(":/?#[]@!$&'()*+,;=", c) >= 0:
+			b.WriteByte(c)
+		case reserved && c == '%' && i+2 < len(s) && isHexDigit(s[i+1]) && isHexDigit(s[i+2]):
+			b.WriteString(s[i : i+3])
+			i += 2
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&0xf])
+		}
+	}
+	return b.String()
+}
+
+func isHexDigit(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// GetURITemplate retrieves an RFC 6570 level 1 or level 2 URI template property.
+func GetURITemplate(props map[string]interface{}, prop string) (*URITemplate, error) {
+	if props == nil {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	s, ok := val.(string)
+	if !ok {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "URI template", Actual: val}
+	}
+	if t, err := ParseURITemplate(s); err == nil {
+		return t, nil
+	} else {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "URI template", Actual: val, Cause: err}
+	}
+}
+
+// MustGetURITemplate retrieves a URI template property or panics.
+func MustGetURITemplate(props map[string]interface{}, prop string) *URITemplate {
+	val, err := GetURITemplate(props, prop)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetURITemplateOrDefault retrieves a URI template property or returns a default value.
+func GetURITemplateOrDefault(props map[string]interface{}, prop string, defaultValue *URITemplate) *URITemplate {
+	val, err := GetURITemplate(props, prop)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
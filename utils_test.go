@@ -1,7 +1,14 @@
 package go_objectutils
 
 import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
 	"math/big"
+	"strings"
 	"testing"
 	"time"
 
@@ -678,3 +685,2297 @@ func TestNewArrayFeatures(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, o1, *(*objPtrArrPtr)[0])
 }
+
+func TestArrayReflection(t *testing.T) {
+	type tags []string
+	now := time.Now()
+	props := map[string]interface{}{
+		"named":     tags{"a", "b"},
+		"fixed":     [2]string{"a", "b"},
+		"ints":      []int{1, 2},
+		"floats":    []float64{1.5, 2.5},
+		"int64s":    [2]int64{3, 4},
+		"maps":      []map[string]interface{}{{"k": 1}, {"k": 2}},
+		"dates":     []string{now.Format(time.RFC3339)},
+		"bools":     [2]bool{true, false},
+		"badInts":   []bool{true},
+		"notArray":  "abc",
+		"nilSlice":  []string(nil),
+		"numPtrs":   []*int{nil},
+		"mixedNums": []interface{}{1, "2", 3.0},
+	}
+
+	strs, err := GetStringArray(props, "named")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, strs)
+
+	strs, err = GetStringArray(props, "fixed")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, strs)
+
+	f, err := GetNumberArray[float64](props, "ints")
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1, 2}, f)
+
+	i, err := GetNumberArray[int](props, "floats")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, i)
+
+	i, err = GetNumberArray[int](props, "int64s")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, i)
+
+	i, err = GetNumberArray[int](props, "mixedNums")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, i)
+
+	type tag string
+	type flag bool
+	type level int16
+	typed := map[string]interface{}{
+		"int32s":  []int32{1, 2},
+		"uint8s":  []uint8{3, 4},
+		"int16s":  [2]int16{-5, 6},
+		"uints":   []uint64{1 << 60},
+		"float32": []float32{0.5},
+		"levels":  []level{7},
+		"tags":    []tag{"x", "y"},
+		"tagPtrs": []interface{}{tag("z"), nil},
+		"flags":   []flag{true, false},
+		"numStrs": []tag{"1.5"},
+	}
+	i, err = GetNumberArray[int](typed, "int32s")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, i)
+	i, err = GetNumberArray[int](typed, "uint8s")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, i)
+	i, err = GetNumberArray[int](typed, "int16s")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{-5, 6}, i)
+	u, err := GetNumberArray[uint64](typed, "uints")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1 << 60}, u)
+	assert.Equal(t, []float64{0.5}, MustGetNumberArray[float64](typed, "float32"))
+	assert.Equal(t, []int{7}, MustGetNumberArray[int](typed, "levels"))
+	assert.Equal(t, []float64{1.5}, MustGetNumberArray[float64](typed, "numStrs"))
+	assert.Equal(t, []string{"x", "y"}, MustGetStringArray(typed, "tags"))
+	sp, err := GetStringPointerArray(typed, "tagPtrs")
+	assert.NoError(t, err)
+	assert.Equal(t, "z", *sp[0])
+	assert.Nil(t, sp[1])
+	assert.Equal(t, []bool{true, false}, MustGetBooleanArray(typed, "flags"))
+	set, err := GetNumberSet[int](typed, "uint8s")
+	assert.NoError(t, err)
+	assert.Len(t, set, 2)
+	pair, err := GetPair[string, bool](map[string]interface{}{"p": []interface{}{tag("a"), flag(true)}}, "p")
+	assert.NoError(t, err)
+	assert.Equal(t, Pair[string, bool]{First: "a", Second: true}, pair)
+
+	_, err = GetNumberArray[int](props, "badInts")
+	assert.IsType(t, &InvalidTypeError{}, err)
+
+	_, err = GetNumberArray[int](props, "notArray")
+	assert.IsType(t, &InvalidTypeError{}, err)
+
+	objs, err := GetObjectArray[interface{}](props, "maps")
+	assert.NoError(t, err)
+	assert.Len(t, objs, 2)
+
+	dates, err := GetDateArray(props, "dates")
+	assert.NoError(t, err)
+	assert.Len(t, dates, 1)
+
+	bools, err := GetBooleanArray(props, "bools")
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false}, bools)
+
+	strs, err = GetStringArray(props, "nilSlice")
+	assert.NoError(t, err)
+	assert.Nil(t, strs)
+
+	// Pointer variants
+	strPtrs, err := GetStringPointerArray(props, "fixed")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", *strPtrs[1])
+
+	floatPtrs, err := GetNumberPointerArray[float64](props, "ints")
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, *floatPtrs[1])
+
+	intPtrs, err := GetNumberPointerArray[int](props, "numPtrs")
+	assert.NoError(t, err)
+	assert.Nil(t, intPtrs[0])
+
+	boolPtrs, err := GetBooleanPointerArray(props, "bools")
+	assert.NoError(t, err)
+	assert.False(t, *boolPtrs[1])
+
+	numArrPtr, err := GetNumberArrayPtr[int](props, "floats")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, *numArrPtr)
+
+	assert.Len(t, GetObjectArrayFunctionPropOrDefault(props, "maps", func(m map[string]interface{}) int {
+		return 1
+	}, nil), 2)
+}
+
+func TestNestedArray(t *testing.T) {
+	props := map[string]interface{}{
+		"matrix":  []interface{}{[]interface{}{1, 2}, []interface{}{3.0, "4"}},
+		"ragged":  []interface{}{[]interface{}{"a"}, []string{"b", "c"}},
+		"typed":   [][]int{{1, 2}, {3, 4}},
+		"dates":   []interface{}{[]interface{}{"2024-01-01T00:00:00Z", int64(0)}},
+		"objects": []interface{}{[]interface{}{map[string]interface{}{"k": 1}}},
+		"badElem": []interface{}{[]interface{}{1, 2}, []interface{}{3, "x"}},
+		"badRow":  []interface{}{[]interface{}{1}, "x"},
+		"notArr":  "x",
+	}
+
+	m, err := GetNumberArray2D[int](props, "matrix", ArrayShape{Rectangular: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}}, m)
+
+	m, err = GetNumberArray2D[int](props, "typed", ArrayShape{InnerLength: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}}, m)
+
+	s, err := GetStringArray2D(props, "ragged")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"a"}, {"b", "c"}}, s)
+
+	_, err = GetStringArray2D(props, "ragged", ArrayShape{Rectangular: true})
+	var shapeErr *ShapeError
+	assert.ErrorAs(t, err, &shapeErr)
+	assert.Equal(t, 1, shapeErr.Index)
+	assert.Equal(t, 1, shapeErr.Expected)
+	assert.Equal(t, 2, shapeErr.Actual)
+
+	_, err = GetNumberArray2D[int](props, "matrix", ArrayShape{InnerLength: 3})
+	assert.ErrorAs(t, err, &shapeErr)
+	assert.Equal(t, 0, shapeErr.Index)
+
+	d, err := GetDateArray2D(props, "dates")
+	assert.NoError(t, err)
+	assert.Equal(t, 2024, d[0][0].Year())
+
+	o, err := GetObjectArray2D[map[string]interface{}](props, "objects")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, o[0][0]["k"])
+
+	_, err = GetNumberArray2D[int](props, "badElem")
+	var elemErr *ElementError
+	assert.ErrorAs(t, err, &elemErr)
+	assert.Equal(t, []int{1, 1}, elemErr.Indices)
+	assert.Contains(t, err.Error(), "element [1][1]")
+
+	_, err = GetNumberArray2D[int](props, "badRow")
+	assert.ErrorAs(t, err, &elemErr)
+	assert.Equal(t, []int{1}, elemErr.Indices)
+
+	_, err = GetStringArray2D(props, "notArr")
+	assert.IsType(t, &InvalidTypeError{}, err)
+
+	_, err = GetStringArray2D(props, "missing")
+	assert.IsType(t, &MissingFieldError{}, err)
+
+	assert.Panics(t, func() { MustGetNumberArray2D[int](props, "badElem") })
+	assert.Equal(t, [][]int{{0}}, GetNumberArray2DOrDefault(props, "badElem", [][]int{{0}}))
+	assert.Len(t, MustGetStringArray2D(props, "ragged"), 2)
+	assert.Nil(t, GetDateArray2DOrDefault(props, "missing", nil))
+}
+
+func TestTuple(t *testing.T) {
+	props := map[string]interface{}{
+		"coord":  []interface{}{144.9, "-37.8"},
+		"range":  []interface{}{"2024-01-01T00:00:00Z", "2024-02-01T00:00:00Z"},
+		"kv":     []interface{}{"k", 3},
+		"fixed":  [2]float64{1, 2},
+		"triple": []interface{}{"a", 1, true},
+		"big":    []interface{}{"12345678901234567890", "x"},
+		"short":  []interface{}{1},
+		"badPos": []interface{}{"k", "v"},
+		"notArr": "x",
+	}
+
+	coord, err := GetPair[float64, float64](props, "coord")
+	assert.NoError(t, err)
+	assert.Equal(t, Pair[float64, float64]{First: 144.9, Second: -37.8}, coord)
+
+	r, err := GetPair[time.Time, time.Time](props, "range")
+	assert.NoError(t, err)
+	assert.Equal(t, time.February, r.Second.Month())
+
+	kv, err := GetPair[string, int](props, "kv")
+	assert.NoError(t, err)
+	assert.Equal(t, "k", kv.First)
+	assert.Equal(t, 3, kv.Second)
+
+	f, err := GetPair[int, int](props, "fixed")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, f.Second)
+
+	b, err := GetPair[*big.Int, string](props, "big")
+	assert.NoError(t, err)
+	assert.Equal(t, "12345678901234567890", b.First.String())
+
+	tr, err := GetTriple[string, int, bool](props, "triple")
+	assert.NoError(t, err)
+	assert.Equal(t, Triple[string, int, bool]{First: "a", Second: 1, Third: true}, tr)
+
+	_, err = GetPair[int, int](props, "short")
+	var shapeErr *ShapeError
+	assert.ErrorAs(t, err, &shapeErr)
+	assert.Equal(t, 2, shapeErr.Expected)
+	assert.Equal(t, 1, shapeErr.Actual)
+
+	_, err = GetTriple[string, int, bool](props, "kv")
+	assert.ErrorAs(t, err, &shapeErr)
+
+	_, err = GetPair[string, int](props, "badPos")
+	var elemErr *ElementError
+	assert.ErrorAs(t, err, &elemErr)
+	assert.Equal(t, []int{1}, elemErr.Indices)
+
+	null, err := GetPair[string, interface{}](map[string]interface{}{"kv": []interface{}{"k", nil}}, "kv")
+	assert.NoError(t, err)
+	assert.Equal(t, Pair[string, interface{}]{First: "k"}, null)
+	_, err = GetPair[string, fmt.Stringer](props, "kv")
+	assert.ErrorAs(t, err, &elemErr)
+	assert.Equal(t, "fmt.Stringer", elemErr.Expected)
+	assert.Contains(t, err.Error(), "cannot convert int to fmt.Stringer")
+	_, err = GetPair[string, map[string]interface{}](map[string]interface{}{"kv": []interface{}{"k", nil}}, "kv")
+	assert.ErrorAs(t, err, &elemErr)
+	grid, err := GetObjectArray2D[interface{}](map[string]interface{}{"g": []interface{}{[]interface{}{nil, 1}}}, "g")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]interface{}{{nil, 1}}, grid)
+
+	_, err = GetPair[string, int](props, "notArr")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	_, err = GetPair[string, int](props, "missing")
+	assert.IsType(t, &MissingFieldError{}, err)
+
+	assert.Panics(t, func() { MustGetPair[string, int](props, "badPos") })
+	assert.Equal(t, "k", MustGetPair[string, int](props, "kv").First)
+	def := Pair[string, int]{First: "d"}
+	assert.Equal(t, def, GetPairOrDefault(props, "badPos", def))
+	assert.Panics(t, func() { MustGetTriple[string, int, bool](props, "kv") })
+	assert.Equal(t, tr, GetTripleOrDefault(props, "triple", Triple[string, int, bool]{}))
+
+	// Named scalar types convert by their underlying kind.
+	type meters float64
+	type label string
+	type enabled bool
+	type count uint8
+	named := map[string]interface{}{"span": []interface{}{1.0, "2.5"}, "tagged": []interface{}{"x", true, 3}}
+	span, err := GetPair[meters, meters](named, "span")
+	assert.NoError(t, err)
+	assert.Equal(t, Pair[meters, meters]{First: 1, Second: 2.5}, span)
+	tagged, err := GetTriple[label, enabled, count](named, "tagged")
+	assert.NoError(t, err)
+	assert.Equal(t, Triple[label, enabled, count]{First: "x", Second: true, Third: 3}, tagged)
+	_, err = GetPair[label, meters](map[string]interface{}{"p": []interface{}{"x", "far"}}, "p")
+	assert.ErrorAs(t, err, &elemErr)
+}
+
+type testEvent interface{ Kind() string }
+
+type testClick struct{ X int }
+
+func (testClick) Kind() string { return "click" }
+
+type testView struct{ Page string }
+
+func (testView) Kind() string { return "view" }
+
+func TestUnion(t *testing.T) {
+	events := NewUnion[testEvent]("type").
+		Register("click", func(m map[string]interface{}) testEvent {
+			return testClick{X: GetNumberOrDefault(m, "x", 0)}
+		}).
+		Register("view", func(m map[string]interface{}) testEvent {
+			return testView{Page: GetStringOrDefault(m, "page", "")}
+		})
+	assert.Equal(t, []string{"click", "view"}, events.Variants())
+
+	props := map[string]interface{}{
+		"event": map[string]interface{}{"type": "click", "x": 5},
+		"events": []interface{}{
+			map[string]interface{}{"type": "view", "page": "/home"},
+			map[string]interface{}{"type": "click", "x": 1},
+		},
+		"unknown":  []interface{}{map[string]interface{}{"type": "scroll"}},
+		"noType":   map[string]interface{}{"x": 1},
+		"badType":  map[string]interface{}{"type": 1},
+		"notObj":   []interface{}{"x"},
+		"notArray": "x",
+	}
+
+	e, err := GetUnion(props, "event", events)
+	assert.NoError(t, err)
+	assert.Equal(t, testClick{X: 5}, e)
+
+	arr, err := GetUnionArray(props, "events", events)
+	assert.NoError(t, err)
+	assert.Equal(t, []testEvent{testView{Page: "/home"}, testClick{X: 1}}, arr)
+
+	_, err = GetUnionArray(props, "unknown", events)
+	var variantErr *UnknownVariantError
+	assert.ErrorAs(t, err, &variantErr)
+	assert.Equal(t, "unknown[0].type", variantErr.Prop)
+	assert.Equal(t, "scroll", variantErr.Value)
+	assert.Equal(t, []string{"click", "view"}, variantErr.Accepted)
+	assert.Contains(t, err.Error(), "click, view")
+
+	_, err = GetUnion(props, "noType", events)
+	assert.IsType(t, &MissingFieldError{}, err)
+	assert.Contains(t, err.Error(), "noType.type")
+
+	_, err = GetUnion(props, "badType", events)
+	assert.IsType(t, &InvalidTypeError{}, err)
+
+	_, err = GetUnionArray(props, "notObj", events)
+	assert.IsType(t, &ElementError{}, err)
+
+	_, err = GetUnionArray(props, "notArray", events)
+	assert.IsType(t, &InvalidTypeError{}, err)
+
+	_, err = events.Decode(map[string]interface{}{"type": "view"})
+	assert.NoError(t, err)
+
+	assert.Panics(t, func() { MustGetUnion(props, "noType", events) })
+	assert.Equal(t, e, MustGetUnion(props, "event", events))
+	assert.Nil(t, GetUnionOrDefault(props, "missing", events, nil))
+	assert.Len(t, MustGetUnionArray(props, "events", events), 2)
+	assert.Panics(t, func() { MustGetUnionArray(props, "unknown", events) })
+	assert.Nil(t, GetUnionArrayOrDefault(props, "unknown", events, nil))
+
+	// A Union literal works without NewUnion.
+	literal := &Union[testEvent]{Discriminator: "type"}
+	_, err = literal.Decode(map[string]interface{}{"type": "click"})
+	assert.IsType(t, &UnknownVariantError{}, err)
+	assert.Empty(t, literal.Variants())
+	assert.NotPanics(t, func() {
+		literal.Register("click", func(map[string]interface{}) testEvent { return testClick{} })
+	})
+	e, err = GetUnion(props, "event", literal)
+	assert.NoError(t, err)
+	assert.Equal(t, testClick{}, e)
+}
+
+func TestConstructorWith(t *testing.T) {
+	type user struct{ Name string }
+	newUser := func(m map[string]interface{}) (user, error) {
+		name, err := GetString(m, "name")
+		if err != nil {
+			return user{}, err
+		}
+		return user{Name: name}, nil
+	}
+	props := map[string]interface{}{
+		"user":    map[string]interface{}{"name": "Alice"},
+		"badUser": map[string]interface{}{},
+		"users": []interface{}{
+			map[string]interface{}{"name": "Alice"},
+			map[string]interface{}{"name": "Bob"},
+		},
+		"badUsers": []interface{}{
+			map[string]interface{}{"name": "Alice"},
+			map[string]interface{}{"name": 1},
+		},
+		"notObjs": []interface{}{"x"},
+		"str":     "x",
+	}
+
+	u, err := GetObjectWith(props, "user", newUser)
+	assert.NoError(t, err)
+	assert.Equal(t, user{Name: "Alice"}, u)
+
+	_, err = GetObjectWith(props, "badUser", newUser)
+	var ctorErr *ConstructorError
+	assert.ErrorAs(t, err, &ctorErr)
+	assert.Equal(t, -1, ctorErr.Index)
+	var missingErr *MissingFieldError
+	assert.ErrorAs(t, err, &missingErr)
+	assert.Equal(t, "name", missingErr.Prop)
+
+	_, err = GetObjectWith(props, "str", newUser)
+	assert.IsType(t, &InvalidTypeError{}, err)
+	_, err = GetObjectWith(props, "missing", newUser)
+	assert.IsType(t, &MissingFieldError{}, err)
+
+	assert.Equal(t, u, MustGetObjectWith(props, "user", newUser))
+	assert.Panics(t, func() { MustGetObjectWith(props, "badUser", newUser) })
+	assert.Equal(t, user{Name: "d"}, GetObjectWithOrDefault(props, "badUser", newUser, user{Name: "d"}))
+	assert.Equal(t, "Alice", MustGetObjectWithPtr(props, "user", newUser).Name)
+	assert.Nil(t, GetObjectWithPtrOrDefault(props, "badUser", newUser, nil))
+
+	users, err := GetObjectArrayWith(props, "users", newUser)
+	assert.NoError(t, err)
+	assert.Equal(t, []user{{Name: "Alice"}, {Name: "Bob"}}, users)
+
+	_, err = GetObjectArrayWith(props, "badUsers", newUser)
+	assert.ErrorAs(t, err, &ctorErr)
+	assert.Equal(t, 1, ctorErr.Index)
+	assert.Equal(t, "badUsers", ctorErr.Prop)
+	assert.Contains(t, err.Error(), "property 'badUsers' element [1] could not be constructed: property 'name'")
+
+	_, err = GetObjectArrayWith(props, "notObjs", newUser)
+	assert.IsType(t, &ElementError{}, err)
+	_, err = GetObjectArrayWith(props, "str", newUser)
+	assert.IsType(t, &InvalidTypeError{}, err)
+
+	assert.Len(t, MustGetObjectArrayWith(props, "users", newUser), 2)
+	assert.Panics(t, func() { MustGetObjectArrayWith(props, "badUsers", newUser) })
+	assert.Nil(t, GetObjectArrayWithOrDefault(props, "badUsers", newUser, nil))
+	assert.Len(t, *MustGetObjectArrayWithPtr(props, "users", newUser), 2)
+	assert.Nil(t, GetObjectArrayWithPtrOrDefault(props, "badUsers", newUser, nil))
+}
+
+type testStatus string
+
+const (
+	testStatusActive   testStatus = "active"
+	testStatusInactive testStatus = "inactive"
+)
+
+type testLevel int
+
+const (
+	testLevelDebug testLevel = iota
+	testLevelInfo
+	testLevelWarn
+)
+
+func TestEnum(t *testing.T) {
+	statuses := NewEnum(testStatusActive, testStatusInactive).Alias("enabled", testStatusActive).CaseInsensitive()
+	levels := NewEnum[testLevel]().
+		Name(testLevelDebug, "debug").
+		Name(testLevelInfo, "info", "information").
+		Name(testLevelWarn, "warn", "warning")
+
+	props := map[string]interface{}{
+		"status":    "active",
+		"upper":     "INACTIVE",
+		"alias":     "Enabled",
+		"typed":     testStatusInactive,
+		"bad":       "deleted",
+		"level":     "warning",
+		"levelNum":  1.0,
+		"levelStr":  "2",
+		"levelFrac": 1.5,
+		"levelOut":  7,
+		"statuses":  []interface{}{"active", "inactive", "active"},
+		"badList":   []interface{}{"active", "deleted"},
+		"levels":    []interface{}{"debug", 2},
+	}
+
+	s, err := GetEnum(props, "status", statuses)
+	assert.NoError(t, err)
+	assert.Equal(t, testStatusActive, s)
+
+	s, err = GetEnum(props, "upper", statuses)
+	assert.NoError(t, err)
+	assert.Equal(t, testStatusInactive, s)
+
+	s, err = GetEnum(props, "alias", statuses)
+	assert.NoError(t, err)
+	assert.Equal(t, testStatusActive, s)
+
+	s, err = GetEnum(props, "typed", statuses)
+	assert.NoError(t, err)
+	assert.Equal(t, testStatusInactive, s)
+
+	_, err = GetEnum(props, "bad", statuses)
+	var enumErr *InvalidEnumError
+	assert.ErrorAs(t, err, &enumErr)
+	assert.Equal(t, []string{"active", "inactive"}, enumErr.Allowed)
+	assert.Equal(t, "property 'bad' value 'deleted' is not one of: active, inactive", err.Error())
+
+	l, err := GetEnum(props, "level", levels)
+	assert.NoError(t, err)
+	assert.Equal(t, testLevelWarn, l)
+
+	l, err = GetEnum(props, "levelNum", levels)
+	assert.NoError(t, err)
+	assert.Equal(t, testLevelInfo, l)
+
+	l, err = GetEnum(props, "levelStr", levels)
+	assert.NoError(t, err)
+	assert.Equal(t, testLevelWarn, l)
+
+	_, err = GetEnum(props, "levelFrac", levels)
+	assert.ErrorAs(t, err, &enumErr)
+	_, err = GetEnum(props, "levelOut", levels)
+	assert.ErrorAs(t, err, &enumErr)
+	assert.Equal(t, []string{"debug", "info", "warn"}, enumErr.Allowed)
+	_, err = GetEnum(props, "missing", levels)
+	assert.IsType(t, &MissingFieldError{}, err)
+
+	arr, err := GetEnumArray(props, "statuses", statuses)
+	assert.NoError(t, err)
+	assert.Equal(t, []testStatus{"active", "inactive", "active"}, arr)
+
+	_, err = GetEnumArray(props, "badList", statuses)
+	assert.ErrorAs(t, err, &enumErr)
+	assert.Equal(t, "badList[1]", enumErr.Prop)
+
+	lv, err := GetEnumArray(props, "levels", levels)
+	assert.NoError(t, err)
+	assert.Equal(t, []testLevel{testLevelDebug, testLevelWarn}, lv)
+
+	set, err := GetEnumSet(props, "statuses", statuses)
+	assert.NoError(t, err)
+	assert.Equal(t, map[testStatus]struct{}{testStatusActive: {}, testStatusInactive: {}}, set)
+
+	assert.Equal(t, testStatusActive, MustGetEnum(props, "status", statuses))
+	assert.Panics(t, func() { MustGetEnum(props, "bad", statuses) })
+	assert.Equal(t, testLevelDebug, GetEnumOrDefault(props, "levelOut", levels, testLevelDebug))
+	p, err := GetEnumPtr(props, "level", levels)
+	assert.NoError(t, err)
+	assert.Equal(t, testLevelWarn, *p)
+	assert.Len(t, MustGetEnumArray(props, "statuses", statuses), 3)
+	assert.Nil(t, GetEnumArrayOrDefault(props, "badList", statuses, nil))
+	assert.Len(t, MustGetEnumSet(props, "statuses", statuses), 2)
+	assert.Nil(t, GetEnumSetOrDefault(props, "badList", statuses, nil))
+	assert.Equal(t, "info", levels.NameOf(testLevelInfo))
+	assert.Equal(t, []testLevel{0, 1, 2}, levels.Values())
+
+	// Case folding uses an index, so lookups are deterministic; exact matches win.
+	folded := NewEnum[testLevel]().CaseInsensitive().
+		Name(testLevelDebug, "debug", "dbg").
+		Name(testLevelInfo, "Info")
+	for i := 0; i < 20; i++ {
+		l, err := folded.Parse("level", "DBG")
+		assert.NoError(t, err)
+		assert.Equal(t, testLevelDebug, l)
+	}
+	assert.Equal(t, testLevelInfo, MustGetEnum(map[string]interface{}{"l": "INFO"}, "l", folded))
+	folded.Alias("DEBUG", testLevelDebug)
+	assert.PanicsWithValue(t, `go_objectutils: enum name "INFO" matches the name of "Info" when case is ignored`, func() {
+		folded.Alias("INFO", testLevelDebug)
+	})
+	assert.Panics(t, func() {
+		NewEnum[testLevel]().Name(testLevelDebug, "low").Name(testLevelInfo, "LOW").CaseInsensitive()
+	})
+	assert.Panics(t, func() { NewEnum(testStatus("on"), testStatus("ON")).CaseInsensitive() })
+	assert.NotPanics(t, func() { NewEnum(testStatus("on"), testStatus("ON")) })
+
+	// Rebinding a name to another value is rejected whether or not case is folded.
+	assert.PanicsWithValue(t, `go_objectutils: enum name "x" is already registered for "debug"`, func() {
+		NewEnum[testLevel]().Name(testLevelDebug, "debug").Alias("x", testLevelDebug).Alias("x", testLevelInfo)
+	})
+	assert.Panics(t, func() {
+		NewEnum[testLevel]().CaseInsensitive().Alias("x", testLevelDebug).Alias("x", testLevelInfo)
+	})
+	assert.Panics(t, func() { NewEnum(testStatusActive).Alias(string(testStatusActive), testStatusInactive) })
+	assert.NotPanics(t, func() {
+		NewEnum[testLevel]().Alias("x", testLevelDebug).Alias("x", testLevelDebug).Name(testLevelDebug, "x")
+	})
+}
+
+func TestSet(t *testing.T) {
+	props := map[string]interface{}{
+		"tags":    []interface{}{"a", "b", "a"},
+		"unique":  []string{"a", "b"},
+		"nums":    []interface{}{1, "2", 1.0},
+		"flags":   []interface{}{true, false},
+		"badElem": []interface{}{"a", 1},
+		"str":     "x",
+	}
+
+	tags, err := GetStringSet(props, "tags")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]struct{}{"a": {}, "b": {}}, tags)
+
+	_, err = GetStringSet(props, "tags", RejectDuplicates)
+	var dupErr *DuplicateElementError
+	assert.ErrorAs(t, err, &dupErr)
+	assert.Equal(t, 0, dupErr.FirstIndex)
+	assert.Equal(t, 2, dupErr.SecondIndex)
+	assert.Equal(t, "a", dupErr.Value)
+	assert.Equal(t, "property 'tags' value 'a' is duplicated at elements [0] and [2]", err.Error())
+
+	unique, err := GetStringSet(props, "unique", RejectDuplicates)
+	assert.NoError(t, err)
+	assert.Len(t, unique, 2)
+
+	nums, err := GetNumberSet[int](props, "nums")
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]struct{}{1: {}, 2: {}}, nums)
+
+	_, err = GetNumberSet[int](props, "nums", RejectDuplicates)
+	assert.ErrorAs(t, err, &dupErr)
+	assert.Equal(t, 2, dupErr.SecondIndex)
+
+	flags, err := GetSet[bool](props, "flags")
+	assert.NoError(t, err)
+	assert.Len(t, flags, 2)
+
+	mixed := map[string]interface{}{
+		"objs": []interface{}{"a", map[string]interface{}{"a": 1}},
+		"arrs": []interface{}{[]interface{}{1}},
+		"ok":   []interface{}{"a", 1.0, true, nil},
+	}
+	var unhashable *ElementError
+	_, err = GetSet[interface{}](mixed, "objs")
+	assert.ErrorAs(t, err, &unhashable)
+	assert.Equal(t, []int{1}, unhashable.Indices)
+	_, err = GetSet[interface{}](mixed, "arrs")
+	assert.ErrorAs(t, err, &unhashable)
+	anySet, err := GetSet[interface{}](mixed, "ok")
+	assert.NoError(t, err)
+	assert.Len(t, anySet, 4)
+	_, err = GetSet[fmt.Stringer](mixed, "ok")
+	assert.ErrorAs(t, err, &unhashable)
+	assert.Equal(t, "fmt.Stringer", unhashable.Expected)
+
+	gen, err := GetSet[int](props, "nums", MergeDuplicates)
+	assert.NoError(t, err)
+	assert.Len(t, gen, 2)
+
+	// Named types are the usual element type of a generic set.
+	type status string
+	type priority int
+	namedSet, err := GetSet[status](props, "tags")
+	assert.NoError(t, err)
+	assert.Equal(t, map[status]struct{}{"a": {}, "b": {}}, namedSet)
+	_, err = GetSet[status](props, "tags", RejectDuplicates)
+	assert.ErrorAs(t, err, &dupErr)
+	priorities, err := GetSet[priority](props, "nums")
+	assert.NoError(t, err)
+	assert.Equal(t, map[priority]struct{}{1: {}, 2: {}}, priorities)
+	_, err = GetSet[priority](props, "flags")
+	assert.IsType(t, &ElementError{}, err)
+
+	_, err = GetSet[string](props, "badElem")
+	var elemErr *ElementError
+	assert.ErrorAs(t, err, &elemErr)
+	assert.Equal(t, []int{1}, elemErr.Indices)
+
+	_, err = GetSet[string](props, "str")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	_, err = GetStringSet(props, "missing")
+	assert.IsType(t, &MissingFieldError{}, err)
+
+	statuses := NewEnum(testStatusActive, testStatusInactive)
+	_, err = GetEnumSet(props, "unique", NewEnum[testStatus]("a", "b"), RejectDuplicates)
+	assert.NoError(t, err)
+	_, err = GetEnumSet(props, "tags", NewEnum[testStatus]("a", "b"), RejectDuplicates)
+	assert.ErrorAs(t, err, &dupErr)
+	assert.Nil(t, GetEnumSetOrDefault(props, "tags", statuses, nil))
+
+	assert.Len(t, MustGetStringSet(props, "tags"), 2)
+	assert.Panics(t, func() { MustGetStringSet(props, "tags", RejectDuplicates) })
+	assert.Nil(t, GetStringSetOrDefault(props, "tags", nil, RejectDuplicates))
+	assert.Len(t, MustGetNumberSet[int](props, "nums"), 2)
+	assert.Nil(t, GetNumberSetOrDefault[int](props, "nums", nil, RejectDuplicates))
+	assert.Len(t, MustGetSet[bool](props, "flags"), 2)
+	assert.Nil(t, GetSetOrDefault[string](props, "badElem", nil))
+}
+
+func TestBytes(t *testing.T) {
+	props := map[string]interface{}{
+		"std":    "aGVsbG8/Pz8=",
+		"url":    "aGVsbG8_Pz8=",
+		"raw":    "aGVsbG8",
+		"hex":    "68656c6c6f",
+		"bytes":  []byte("hello"),
+		"bad":    "!!!",
+		"num":    1,
+		"list":   []interface{}{"68656c6c6f", []byte("hi")},
+		"big":    "aGVsbG8gd29ybGQgaGVsbG8gd29ybGQ=",
+		"badArr": []interface{}{"68656c6c6f", 1},
+	}
+
+	b, err := GetBytes(props, "std", BytesOptions{Encoding: EncodingBase64})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello???"), b)
+
+	b, err = GetBytes(props, "url", BytesOptions{Encoding: EncodingBase64URL})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello???"), b)
+
+	_, err = GetBytes(props, "url", BytesOptions{Encoding: EncodingBase64})
+	assert.IsType(t, &InvalidTypeError{}, err)
+
+	b, err = GetBytes(props, "raw", BytesOptions{Encoding: EncodingBase64Raw})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello"), b)
+
+	b, err = GetBytes(props, "raw", BytesOptions{Encoding: EncodingBase64RawURL})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello"), b)
+
+	b, err = GetBytes(props, "hex", BytesOptions{Encoding: EncodingHex})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello"), b)
+
+	// Standard base64 is the default.
+	b, err = GetBytes(props, "std")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello???"), b)
+	for _, k := range []string{"url", "raw", "hex"} {
+		_, err = GetBytes(props, k)
+		assert.IsType(t, &InvalidTypeError{}, err, k)
+	}
+
+	// Auto-detection
+	auto := BytesOptions{Encoding: EncodingAuto}
+	for _, k := range []string{"std", "url", "raw", "hex", "bytes"} {
+		_, err = GetBytes(props, k, auto)
+		assert.NoError(t, err, k)
+	}
+	b, _ = GetBytes(props, "hex", auto)
+	assert.Equal(t, []byte("hello"), b)
+	b, _ = GetBytes(props, "raw", auto)
+	assert.Equal(t, []byte("hello"), b)
+	// Strings that are valid base64 and valid hex are read as base64.
+	ambiguous := map[string]interface{}{"zeros": "AAAA", "beef": "deadbeef"}
+	for _, opts := range [][]BytesOptions{nil, {auto}} {
+		b, err = GetBytes(ambiguous, "zeros", opts...)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0, 0, 0}, b)
+		b, err = GetBytes(ambiguous, "beef", opts...)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x75, 0xe6, 0x9d, 0x6d, 0xe7, 0x9f}, b)
+	}
+	b, err = GetBytes(ambiguous, "beef", BytesOptions{Encoding: EncodingHex})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, b)
+
+	_, err = GetBytes(props, "bad")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	_, err = GetBytes(props, "num")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	_, err = GetBytes(props, "missing")
+	assert.IsType(t, &MissingFieldError{}, err)
+
+	// Max length
+	_, err = GetBytes(props, "big", BytesOptions{MaxLength: 4})
+	assert.IsType(t, &ValidationError{}, err)
+	_, err = GetBytes(props, "bytes", BytesOptions{MaxLength: 4})
+	assert.IsType(t, &ValidationError{}, err)
+	_, err = GetBytes(props, "bytes", BytesOptions{MaxLength: 5})
+	assert.NoError(t, err)
+	_, err = GetBytes(props, "std", BytesOptions{MaxLength: 8})
+	assert.NoError(t, err)
+	// Auto mode only rejects up front what is too large under every encoding.
+	hexProps := map[string]interface{}{"h": strings.Repeat("ab", 15)}
+	b, err = GetBytes(hexProps, "h", BytesOptions{Encoding: EncodingAuto, MaxLength: 16})
+	assert.NoError(t, err)
+	assert.Len(t, b, 15)
+	_, err = GetBytes(hexProps, "h", BytesOptions{Encoding: EncodingHex, MaxLength: 16})
+	assert.NoError(t, err)
+	_, err = GetBytes(hexProps, "h", BytesOptions{Encoding: EncodingAuto, MaxLength: 14})
+	assert.IsType(t, &ValidationError{}, err)
+
+	arr, err := GetBytesArray(props, "list", auto)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("hello"), []byte("hi")}, arr)
+
+	_, err = GetBytesArray(props, "list", BytesOptions{Encoding: EncodingAuto, MaxLength: 2})
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "list[0]", validationErr.Prop)
+
+	_, err = GetBytesArray(props, "badArr", auto)
+	assert.IsType(t, &InvalidTypeError{}, err)
+	assert.Contains(t, err.Error(), "badArr[1]")
+
+	assert.Equal(t, []byte("hello"), MustGetBytes(props, "hex", auto))
+	assert.Panics(t, func() { MustGetBytes(props, "bad") })
+	assert.Nil(t, GetBytesOrDefault(props, "bad", nil))
+	p, err := GetBytesPtr(props, "hex", auto)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello"), *p)
+	assert.NotNil(t, MustGetBytesPtr(props, "hex", auto))
+	assert.Nil(t, GetBytesPtrOrDefault(props, "bad", nil))
+	assert.Len(t, MustGetBytesArray(props, "list", auto), 2)
+	assert.Nil(t, GetBytesArrayOrDefault(props, "badArr", nil))
+}
+
+func TestCivil(t *testing.T) {
+	props := map[string]interface{}{
+		"birthday": "1990-04-12",
+		"basic":    "19900412",
+		"invalid":  "1990-02-30",
+		"rfc":      "1990-04-12T10:00:00Z",
+		"typed":    Date{Year: 2024, Month: time.February, Day: 29},
+		"badTyped": Date{Year: 2023, Month: time.February, Day: 29},
+		"opens":    "09:30",
+		"closes":   "17:45:30.25",
+		"compact":  "0930",
+		"badTime":  "25:00",
+		"dates":    []interface{}{"2024-01-01", "2024-01-02"},
+		"times":    []string{"09:00", "bad"},
+	}
+
+	d, err := GetCivilDate(props, "birthday")
+	assert.NoError(t, err)
+	assert.Equal(t, Date{Year: 1990, Month: time.April, Day: 12}, d)
+	assert.Equal(t, "1990-04-12", d.String())
+
+	d2, err := GetCivilDate(props, "basic")
+	assert.NoError(t, err)
+	assert.Equal(t, d, d2)
+
+	_, err = GetCivilDate(props, "invalid")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	_, err = GetCivilDate(props, "rfc")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	_, err = GetCivilDate(props, "badTyped")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	_, err = GetCivilDate(props, "missing")
+	assert.IsType(t, &MissingFieldError{}, err)
+
+	leap := MustGetCivilDate(props, "typed")
+	assert.True(t, d.Before(leap))
+	assert.True(t, leap.After(d))
+	assert.Equal(t, 0, d.Compare(d2))
+	assert.Equal(t, DateOf(time.Date(2024, 2, 29, 23, 0, 0, 0, time.UTC)), leap)
+
+	loc := time.FixedZone("AEST", 10*60*60)
+	assert.Equal(t, time.Date(1990, 4, 12, 0, 0, 0, 0, loc), d.In(loc))
+
+	open, err := GetTimeOfDay(props, "opens")
+	assert.NoError(t, err)
+	assert.Equal(t, TimeOfDay{Hour: 9, Minute: 30}, open)
+	assert.Equal(t, "09:30:00", open.String())
+
+	closes, err := GetTimeOfDay(props, "closes")
+	assert.NoError(t, err)
+	assert.Equal(t, 250000000, closes.Nanosecond)
+	assert.Equal(t, "17:45:30.25", closes.String())
+	assert.True(t, open.Before(closes))
+	assert.True(t, closes.After(open))
+
+	compact, err := GetTimeOfDay(props, "compact")
+	assert.NoError(t, err)
+	assert.Equal(t, open, compact)
+
+	_, err = GetTimeOfDay(props, "badTime")
+	assert.IsType(t, &InvalidTypeError{}, err)
+
+	assert.Equal(t, time.Date(1990, 4, 12, 9, 30, 0, 0, loc), d.At(open, loc))
+	assert.Equal(t, d.At(open, loc), open.On(d, loc))
+
+	dates, err := GetCivilDateArray(props, "dates")
+	assert.NoError(t, err)
+	assert.Len(t, dates, 2)
+
+	_, err = GetTimeOfDayArray(props, "times")
+	var elemErr *ElementError
+	assert.ErrorAs(t, err, &elemErr)
+	assert.Equal(t, []int{1}, elemErr.Indices)
+
+	text, err := d.MarshalText()
+	assert.NoError(t, err)
+	var round Date
+	assert.NoError(t, round.UnmarshalText(text))
+	assert.Equal(t, d, round)
+	var roundTime TimeOfDay
+	assert.NoError(t, roundTime.UnmarshalText([]byte("17:45:30.25")))
+	assert.Equal(t, closes, roundTime)
+	assert.Error(t, roundTime.UnmarshalText([]byte("x")))
+
+	assert.Panics(t, func() { MustGetCivilDate(props, "invalid") })
+	assert.Equal(t, Date{}, GetCivilDateOrDefault(props, "invalid", Date{}))
+	assert.NotNil(t, MustGetCivilDatePtr(props, "birthday"))
+	assert.Nil(t, GetCivilDatePtrOrDefault(props, "invalid", nil))
+	assert.Len(t, MustGetCivilDateArray(props, "dates"), 2)
+	assert.Nil(t, GetCivilDateArrayOrDefault(props, "times", nil))
+	assert.Equal(t, open, MustGetTimeOfDay(props, "opens"))
+	assert.Equal(t, open, GetTimeOfDayOrDefault(props, "badTime", open))
+	assert.NotNil(t, MustGetTimeOfDayPtr(props, "opens"))
+	assert.Nil(t, GetTimeOfDayPtrOrDefault(props, "badTime", nil))
+	assert.Panics(t, func() { MustGetTimeOfDayArray(props, "times") })
+	assert.Nil(t, GetTimeOfDayArrayOrDefault(props, "times", nil))
+}
+
+func TestLocation(t *testing.T) {
+	props := map[string]interface{}{
+		"utc":      "UTC",
+		"z":        "Z",
+		"offset":   "+10:00",
+		"compact":  "-0530",
+		"hours":    "+10",
+		"range":    "+19:00",
+		"bad":      "Not/AZone",
+		"num":      10,
+		"loc":      time.UTC,
+		"start":    "2024-01-01T00:00:00Z",
+		"timezone": "+10:00",
+	}
+
+	loc, err := GetLocation(props, "utc")
+	assert.NoError(t, err)
+	assert.Equal(t, time.UTC, loc)
+
+	loc, err = GetLocation(props, "z")
+	assert.NoError(t, err)
+	assert.Equal(t, time.UTC, loc)
+
+	loc, err = GetLocation(props, "offset")
+	assert.NoError(t, err)
+	_, offset := time.Date(2024, 1, 1, 0, 0, 0, 0, loc).Zone()
+	assert.Equal(t, 10*60*60, offset)
+
+	loc, err = GetLocation(props, "compact")
+	assert.NoError(t, err)
+	_, offset = time.Date(2024, 1, 1, 0, 0, 0, 0, loc).Zone()
+	assert.Equal(t, -(5*60*60 + 30*60), offset)
+
+	loc, err = GetLocation(props, "hours")
+	assert.NoError(t, err)
+	_, offset = time.Date(2024, 1, 1, 0, 0, 0, 0, loc).Zone()
+	assert.Equal(t, 10*60*60, offset)
+
+	if _, err := time.LoadLocation("Australia/Melbourne"); err == nil {
+		loc, err = GetLocation(map[string]interface{}{"tz": "Australia/Melbourne"}, "tz")
+		assert.NoError(t, err)
+		assert.Equal(t, "Australia/Melbourne", loc.String())
+	}
+
+	for _, k := range []string{"range", "bad", "num"} {
+		_, err = GetLocation(props, k)
+		assert.IsType(t, &InvalidTypeError{}, err, k)
+	}
+	_, err = GetLocation(props, "missing")
+	assert.IsType(t, &MissingFieldError{}, err)
+
+	assert.Equal(t, time.UTC, MustGetLocation(props, "loc"))
+	assert.Panics(t, func() { MustGetLocation(props, "bad") })
+	assert.Equal(t, time.Local, GetLocationOrDefault(props, "bad", time.Local))
+
+	d, err := GetDateIn(props, "start", "timezone")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, d.Hour())
+	assert.True(t, d.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	_, err = GetDateIn(props, "start", "bad")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	_, err = GetDateIn(props, "start", "missing")
+	assert.IsType(t, &MissingFieldError{}, err)
+	assert.Equal(t, d, MustGetDateIn(props, "start", "timezone"))
+	assert.Panics(t, func() { MustGetDateIn(props, "start", "missing") })
+	assert.Equal(t, time.Time{}, GetDateInOrDefault(props, "start", "missing", time.Time{}))
+}
+
+func TestTimeRange(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	props := map[string]interface{}{
+		"object":   map[string]interface{}{"start": "2024-01-01T00:00:00Z", "end": "2024-02-01T00:00:00Z"},
+		"custom":   map[string]interface{}{"from": jan, "to": feb.UnixMilli()},
+		"calendar": map[string]interface{}{"start": "2024-01-01", "end": "2024-02-01"},
+		"dates":    "2024-01-01/2024-02-01",
+		"duration": "2024-01-01/P1M",
+		"before":   "P1M/2024-02-01T00:00:00Z",
+		"clock":    "2024-01-01T00:00:00Z/PT1H30M",
+		"reversed": "2024-02-01/2024-01-01",
+		"empty":    "2024-01-01/2024-01-01",
+		"badObj":   map[string]interface{}{"start": "2024-01-01T00:00:00Z"},
+		"badDate":  map[string]interface{}{"start": "2024-01-01", "end": "February"},
+		"badStr":   "2024-01-01",
+		"twoDur":   "P1D/P1D",
+		"num":      1,
+	}
+	month := TimeRange{Start: jan, End: feb}
+
+	for _, k := range []string{"object", "calendar", "dates", "duration", "before"} {
+		r, err := GetTimeRange(props, k)
+		assert.NoError(t, err, k)
+		assert.True(t, r.Start.Equal(jan) && r.End.Equal(feb), k)
+	}
+
+	r, err := GetTimeRange(props, "custom", TimeRangeOptions{StartKey: "from", EndKey: "to"})
+	assert.NoError(t, err)
+	assert.True(t, r.End.Equal(feb))
+
+	r, err = GetTimeRange(props, "clock")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, r.Duration())
+	assert.True(t, r.Contains(jan))
+	assert.False(t, r.Contains(r.End))
+
+	_, err = GetTimeRange(props, "reversed")
+	assert.IsType(t, &ValidationError{}, err)
+	_, err = GetTimeRange(props, "empty")
+	assert.IsType(t, &ValidationError{}, err)
+	_, err = GetTimeRange(props, "empty", TimeRangeOptions{AllowEmpty: true})
+	assert.NoError(t, err)
+
+	_, err = GetTimeRange(props, "object", TimeRangeOptions{MaxSpan: 7 * 24 * time.Hour})
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, validationErr.Reason, "exceeds")
+
+	for _, k := range []string{"badObj", "badDate", "badStr", "twoDur", "num"} {
+		_, err = GetTimeRange(props, k)
+		assert.IsType(t, &InvalidTypeError{}, err, k)
+	}
+	_, err = GetTimeRange(props, "missing")
+	assert.IsType(t, &MissingFieldError{}, err)
+
+	d, err := ParseISODuration("P1Y2M3W4DT5H6M7.5S")
+	assert.NoError(t, err)
+	assert.Equal(t, ISODuration{Years: 1, Months: 2, Days: 25, Clock: 5*time.Hour + 6*time.Minute + 7500*time.Millisecond}, d)
+	for _, bad := range []string{"P", "PT", "P1DT", "1D", "P1H"} {
+		_, err = ParseISODuration(bad)
+		assert.Error(t, err, bad)
+	}
+
+	assert.Equal(t, "2024-01-01T00:00:00Z/2024-02-01T00:00:00Z", month.String())
+	assert.Equal(t, month, MustGetTimeRange(props, "object"))
+	assert.Panics(t, func() { MustGetTimeRange(props, "reversed") })
+	assert.Equal(t, month, GetTimeRangeOrDefault(props, "reversed", month))
+	p, err := GetTimeRangePtr(props, "object")
+	assert.NoError(t, err)
+	assert.Equal(t, month, *p)
+}
+
+func TestSchedule(t *testing.T) {
+	base := time.Date(2024, 1, 1, 10, 2, 30, 500, time.UTC) // Monday
+	props := map[string]interface{}{
+		"every5":   "*/5 * * * *",
+		"seconds":  "*/15 * * * * *",
+		"hourly":   "@hourly",
+		"weekday":  "30 9 * * MON-FRI",
+		"monthly":  "0 0 1 * *",
+		"sunday":   "0 12 * * 7",
+		"either":   "0 0 13 * FRI",
+		"list":     "0 8,12,18 * JAN,JUL ?",
+		"never":    "0 0 30 2 *",
+		"badRange": "61 * * * *",
+		"badName":  "0 0 * FOO *",
+		"badStep":  "*/0 * * * *",
+		"badCount": "* * *",
+		"badDesc":  "@sometimes",
+		"num":      5,
+	}
+
+	cases := map[string]time.Time{
+		"every5":  time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC),
+		"seconds": time.Date(2024, 1, 1, 10, 2, 45, 0, time.UTC),
+		"hourly":  time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+		"weekday": time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC),
+		"monthly": time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		"sunday":  time.Date(2024, 1, 7, 12, 0, 0, 0, time.UTC),
+		"either":  time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+		"list":    time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+	for k, want := range cases {
+		s, err := GetSchedule(props, k)
+		assert.NoError(t, err, k)
+		assert.Equal(t, want, s.Next(base), k)
+	}
+
+	s := MustGetSchedule(props, "list")
+	assert.Equal(t, time.Date(2024, 7, 1, 8, 0, 0, 0, time.UTC), s.Next(time.Date(2024, 1, 31, 18, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "0 8,12,18 * JAN,JUL ?", s.String())
+
+	assert.True(t, MustGetSchedule(props, "never").Next(base).IsZero())
+
+	_, err := GetSchedule(props, "badRange")
+	var cronErr *CronFieldError
+	assert.ErrorAs(t, err, &cronErr)
+	assert.Equal(t, "minute", cronErr.Field)
+	assert.Equal(t, "61", cronErr.Value)
+
+	_, err = GetSchedule(props, "badName")
+	assert.ErrorAs(t, err, &cronErr)
+	assert.Equal(t, "month", cronErr.Field)
+
+	_, err = GetSchedule(props, "badStep")
+	assert.ErrorAs(t, err, &cronErr)
+	assert.Equal(t, "minute", cronErr.Field)
+
+	_, err = GetSchedule(props, "badDesc")
+	assert.ErrorAs(t, err, &cronErr)
+
+	_, err = GetSchedule(props, "badCount")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	_, err = GetSchedule(props, "num")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	_, err = GetSchedule(props, "missing")
+	assert.IsType(t, &MissingFieldError{}, err)
+
+	_, err = ParseSchedule("0 0 ? * * *")
+	assert.ErrorAs(t, err, &cronErr)
+	assert.Equal(t, "hour", cronErr.Field)
+	_, err = ParseSchedule("0 5-1 * * *")
+	assert.ErrorAs(t, err, &cronErr)
+
+	assert.Panics(t, func() { MustGetSchedule(props, "badRange") })
+	assert.Nil(t, GetScheduleOrDefault(props, "badRange", nil))
+}
+
+func TestVersion(t *testing.T) {
+	props := map[string]interface{}{
+		"version":    "v1.4.2-rc.1+build.5",
+		"plain":      "1.4.2",
+		"bad":        "1.4",
+		"leading":    "01.2.3",
+		"preLeading": "1.2.3-01",
+		"num":        1,
+		"constraint": ">=1.2, <2.0",
+		"badCons":    ">=foo",
+	}
+
+	v, err := GetVersion(props, "version")
+	assert.NoError(t, err)
+	assert.Equal(t, Version{Major: 1, Minor: 4, Patch: 2, Prerelease: []string{"rc", "1"}, Build: []string{"build", "5"}}, v)
+	assert.Equal(t, "1.4.2-rc.1+build.5", v.String())
+
+	plain := MustGetVersion(props, "plain")
+	assert.True(t, v.LessThan(plain))
+
+	for _, k := range []string{"bad", "leading", "preLeading", "num"} {
+		_, err = GetVersion(props, k)
+		assert.IsType(t, &InvalidTypeError{}, err, k)
+	}
+	_, err = GetVersion(props, "missing")
+	assert.IsType(t, &MissingFieldError{}, err)
+
+	// SemVer 2.0 precedence example
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "2.0.0", "2.1.0", "2.1.1"}
+	for i := 0; i < len(ordered)-1; i++ {
+		a, err := ParseVersion(ordered[i])
+		assert.NoError(t, err)
+		b, err := ParseVersion(ordered[i+1])
+		assert.NoError(t, err)
+		assert.True(t, a.LessThan(b), "%s < %s", a, b)
+		assert.Equal(t, 1, b.Compare(a))
+	}
+	a, _ := ParseVersion("1.0.0+a")
+	b, _ := ParseVersion("1.0.0+b")
+	assert.True(t, a.Equal(b))
+
+	c, err := GetVersionConstraint(props, "constraint")
+	assert.NoError(t, err)
+	assert.True(t, c.Check(plain))
+	assert.False(t, c.Check(Version{Major: 2}))
+	assert.False(t, c.Check(Version{Major: 1, Minor: 1, Patch: 9}))
+	assert.Equal(t, ">=1.2, <2.0", c.String())
+
+	checks := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"^1.2.3", "1.9.0", true},
+		{"^1.2.3", "2.0.0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.4", false},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1", "1.9.0", true},
+		{"1.2", "1.2.7", true},
+		{"1.x", "1.9.9", true},
+		{"1.x", "2.0.0", false},
+		{"=1.2.3", "1.2.3", true},
+		{"!=1.2.3", "1.2.3", false},
+		{">1.2", "1.2.9", false},
+		{">1.2", "1.3.0", true},
+		{"<=1.2", "1.2.9", true},
+		{"<=1.2", "1.3.0", false},
+		{">= 1.0 < 1.5 || >=2.0", "2.3.0", true},
+		{">= 1.0 < 1.5 || >=2.0", "1.7.0", false},
+	}
+	for _, tc := range checks {
+		cons, err := ParseVersionConstraint(tc.constraint)
+		assert.NoError(t, err, tc.constraint)
+		ver, err := ParseVersion(tc.version)
+		assert.NoError(t, err, tc.version)
+		assert.Equal(t, tc.want, cons.Check(ver), "%s %s", tc.constraint, tc.version)
+	}
+
+	_, err = GetVersionConstraint(props, "badCons")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	_, err = ParseVersionConstraint(">=1.0 ||")
+	assert.Error(t, err)
+
+	_, err = GetVersionSatisfying(props, "plain", c)
+	assert.NoError(t, err)
+	cons, _ := ParseVersionConstraint(">=2")
+	_, err = GetVersionSatisfying(props, "plain", cons)
+	assert.IsType(t, &ValidationError{}, err)
+
+	var round Version
+	text, _ := v.MarshalText()
+	assert.NoError(t, round.UnmarshalText(text))
+	assert.Equal(t, v, round)
+
+	assert.Panics(t, func() { MustGetVersion(props, "bad") })
+	assert.Equal(t, plain, GetVersionOrDefault(props, "bad", plain))
+	p, err := GetVersionPtr(props, "plain")
+	assert.NoError(t, err)
+	assert.Equal(t, plain, *p)
+	assert.NotNil(t, MustGetVersionConstraint(props, "constraint"))
+	assert.Panics(t, func() { MustGetVersionConstraint(props, "badCons") })
+	assert.Nil(t, GetVersionConstraintOrDefault(props, "badCons", nil))
+}
+
+func TestColor(t *testing.T) {
+	props := map[string]interface{}{
+		"short":   "#f0c",
+		"shortA":  "#f0c8",
+		"long":    "#FF00CC",
+		"longA":   "#ff00cc80",
+		"rgb":     "rgb(255, 0, 204)",
+		"rgba":    "rgba(255,0,204,0.5)",
+		"percent": "rgb(100%, 0%, 80%)",
+		"native":  color.RGBA{R: 255, A: 255},
+		"badHex":  "#ff00c",
+		"badRgb":  "rgb(256, 0, 0)",
+		"badArgs": "rgb(1, 2, 3, 0.5)",
+		"badNaN":  "rgb(NaN, 0, 0)",
+		"badPct":  "rgb(NaN%, 0%, 0%)",
+		"num":     1,
+	}
+	magenta := Color{R: 0xff, G: 0x00, B: 0xcc, A: 0xff}
+
+	for _, k := range []string{"short", "long", "rgb", "percent"} {
+		c, err := GetColor(props, k)
+		assert.NoError(t, err, k)
+		assert.Equal(t, magenta, c, k)
+	}
+	c, err := GetColor(props, "shortA")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(0x88), c.A)
+
+	c, err = GetColor(props, "longA")
+	assert.NoError(t, err)
+	assert.Equal(t, "#ff00cc80", c.String())
+
+	c, err = GetColor(props, "rgba")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(128), c.A)
+
+	c, err = GetColor(props, "native")
+	assert.NoError(t, err)
+	assert.Equal(t, Color{R: 255, A: 255}, c)
+	r, _, _, a := c.RGBA()
+	assert.Equal(t, uint32(0xffff), r)
+	assert.Equal(t, uint32(0xffff), a)
+
+	_, err = ParseColor("rgba(0, 0, 0, NaN)")
+	assert.Error(t, err)
+	for _, k := range []string{"badHex", "badRgb", "badArgs", "badNaN", "badPct", "num"} {
+		_, err = GetColor(props, k)
+		assert.IsType(t, &InvalidTypeError{}, err, k)
+	}
+	_, err = GetColor(props, "missing")
+	assert.IsType(t, &MissingFieldError{}, err)
+
+	assert.Equal(t, "#ff00cc", MustGetColor(props, "long").String())
+	assert.Panics(t, func() { MustGetColor(props, "badHex") })
+	assert.Equal(t, magenta, GetColorOrDefault(props, "badHex", magenta))
+	p, err := GetColorPtr(props, "long")
+	assert.NoError(t, err)
+	assert.Equal(t, magenta, *p)
+}
+
+func TestGeoPoint(t *testing.T) {
+	melbourne := GeoPoint{Lat: -37.8136, Lng: 144.9631}
+	props := map[string]interface{}{
+		"object":  map[string]interface{}{"lat": -37.8136, "lng": 144.9631},
+		"long":    map[string]interface{}{"latitude": "-37.8136", "longitude": 144.9631},
+		"array":   []interface{}{144.9631, -37.8136},
+		"string":  "-37.8136, 144.9631",
+		"badLat":  map[string]interface{}{"lat": 91, "lng": 0},
+		"badLng":  []float64{-181, 0},
+		"noLng":   map[string]interface{}{"lat": 1},
+		"badStr":  "abc",
+		"short":   []interface{}{1},
+		"path":    []interface{}{"0,0", []interface{}{1, 1}, map[string]interface{}{"lat": 2, "lon": 2}},
+		"badPath": []interface{}{"0,0", "95,0"},
+		"nanStr":  "NaN,NaN",
+		"nanObj":  map[string]interface{}{"lat": "NaN", "lng": 0},
+		"nanArr":  []float64{0, math.NaN()},
+	}
+
+	for _, k := range []string{"object", "long", "array", "string"} {
+		p, err := GetGeoPoint(props, k)
+		assert.NoError(t, err, k)
+		assert.Equal(t, melbourne, p, k)
+	}
+
+	_, err := GetGeoPoint(props, "badLat")
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, validationErr.Reason, "latitude")
+	_, err = GetGeoPoint(props, "badLng")
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, validationErr.Reason, "longitude")
+
+	for _, k := range []string{"nanStr", "nanObj", "nanArr"} {
+		_, err = GetGeoPoint(props, k)
+		assert.ErrorAs(t, err, &validationErr, k)
+	}
+
+	_, err = GetGeoPoint(props, "noLng")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	_, err = GetGeoPoint(props, "badStr")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	_, err = GetGeoPoint(props, "short")
+	assert.IsType(t, &ShapeError{}, err)
+	_, err = GetGeoPoint(props, "missing")
+	assert.IsType(t, &MissingFieldError{}, err)
+
+	path, err := GetGeoPointArray(props, "path")
+	assert.NoError(t, err)
+	assert.Equal(t, []GeoPoint{{0, 0}, {1, 1}, {2, 2}}, path)
+	_, err = GetGeoPointArray(props, "badPath")
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "badPath[1]", validationErr.Prop)
+
+	assert.Equal(t, "-37.8136,144.9631", melbourne.String())
+	assert.Equal(t, melbourne, MustGetGeoPoint(props, "object"))
+	assert.Panics(t, func() { MustGetGeoPoint(props, "badLat") })
+	assert.Equal(t, melbourne, GetGeoPointOrDefault(props, "badLat", melbourne))
+	p, err := GetGeoPointPtr(props, "array")
+	assert.NoError(t, err)
+	assert.Equal(t, melbourne, *p)
+	assert.Len(t, MustGetGeoPointArray(props, "path"), 3)
+	assert.Nil(t, GetGeoPointArrayOrDefault(props, "badPath", nil))
+}
+
+func TestEmail(t *testing.T) {
+	props := map[string]interface{}{
+		"named":  "Alice <alice@example.com>",
+		"bare":   "bob@example.com",
+		"bad":    "not an email",
+		"num":    1,
+		"list":   []interface{}{"a@example.com", "B <b@example.com>"},
+		"badArr": []interface{}{"a@example.com", "x"},
+	}
+
+	addr, err := GetEmail(props, "named")
+	assert.NoError(t, err)
+	assert.Equal(t, "Alice", addr.Name)
+	assert.Equal(t, "alice@example.com", addr.Address)
+
+	_, err = GetEmail(props, "named", EmailOptions{AddrSpecOnly: true})
+	assert.IsType(t, &ValidationError{}, err)
+
+	addr, err = GetEmail(props, "bare", EmailOptions{AddrSpecOnly: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "bob@example.com", addr.Address)
+
+	_, err = GetEmail(props, "bad")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	_, err = GetEmail(props, "num")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	_, err = GetEmail(props, "missing")
+	assert.IsType(t, &MissingFieldError{}, err)
+
+	list, err := GetEmailArray(props, "list")
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	_, err = GetEmailArray(props, "list", EmailOptions{AddrSpecOnly: true})
+	assert.IsType(t, &ValidationError{}, err)
+	_, err = GetEmailArray(props, "badArr")
+	assert.Contains(t, err.Error(), "badArr[1]")
+
+	assert.Equal(t, "bob@example.com", MustGetEmail(props, "bare").Address)
+	assert.Panics(t, func() { MustGetEmail(props, "bad") })
+	assert.Nil(t, GetEmailOrDefault(props, "bad", nil))
+}
+
+func TestURITemplate(t *testing.T) {
+	values := map[string]interface{}{
+		"var":   "value",
+		"hello": "Hello World!",
+		"path":  "/foo/bar",
+		"half":  "50%",
+		"pct":   "a%2Fb",
+		"id":    42,
+		"obj":   map[string]interface{}{},
+	}
+	// Examples from RFC 6570 sections 1.2 and 3.2.
+	cases := map[string]string{
+		"{var}":                       "value",
+		"{hello}":                     "Hello%20World%21",
+		"{half}":                      "50%25",
+		"O{undef}X":                   "OX",
+		"{+var}":                      "value",
+		"{+hello}":                    "Hello%20World!",
+		"{+half}":                     "50%25",
+		"{+pct}":                      "a%2Fb",
+		"{+path}/here":                "/foo/bar/here",
+		"here?ref={+path}":            "here?ref=/foo/bar",
+		"X{#var}":                     "X#value",
+		"X{#hello}":                   "X#Hello%20World!",
+		"X{#undef}":                   "X",
+		"https://api/users/{id}/hook": "https://api/users/42/hook",
+	}
+	for tmpl, want := range cases {
+		parsed, err := ParseURITemplate(tmpl)
+		assert.NoError(t, err, tmpl)
+		got, err := parsed.Expand(values)
+		assert.NoError(t, err, tmpl)
+		assert.Equal(t, want, got, tmpl)
+	}
+
+	props := map[string]interface{}{
+		"webhook":  "https://example.com/{tenant}/hooks{#section}",
+		"level3":   "/search{?q}",
+		"unclosed": "/a/{b",
+		"stray":    "/a/}",
+		"badName":  "/{a b}",
+		"num":      1,
+		"useObj":   "/{obj}",
+	}
+	tmpl, err := GetURITemplate(props, "webhook")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tenant", "section"}, tmpl.Variables())
+	assert.Equal(t, "https://example.com/{tenant}/hooks{#section}", tmpl.String())
+	url, err := tmpl.Expand(map[string]interface{}{"tenant": "acme co", "section": "top"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/acme%20co/hooks#top", url)
+
+	for _, k := range []string{"level3", "unclosed", "stray", "badName", "num"} {
+		_, err = GetURITemplate(props, k)
+		assert.IsType(t, &InvalidTypeError{}, err, k)
+	}
+	_, err = GetURITemplate(props, "missing")
+	assert.IsType(t, &MissingFieldError{}, err)
+
+	_, err = MustGetURITemplate(props, "useObj").Expand(values)
+	assert.IsType(t, &InvalidTypeError{}, err)
+	assert.Panics(t, func() { MustGetURITemplate(props, "level3") })
+	assert.Nil(t, GetURITemplateOrDefault(props, "level3", nil))
+}
+
+func TestSetPath(t *testing.T) {
+	doc := map[string]interface{}{
+		"name": "x",
+		"tags": []string{"a"},
+	}
+	assert.NoError(t, SetString(doc, "user.name", "alice"))
+	assert.NoError(t, SetNumber(doc, "user.roles[0].level", 3))
+	assert.NoError(t, SetBoolean(doc, "user.roles[]", true))
+	assert.NoError(t, SetString(doc, "tags[1]", "b"))
+	assert.NoError(t, SetString(doc, "tags[0]", "z"))
+	assert.NoError(t, SetObject(doc, "meta", map[string]interface{}{"v": 1}))
+	assert.NoError(t, SetPath(doc, `a\.b`, 1))
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.NoError(t, SetDate(doc, "when", date))
+
+	assert.Equal(t, "alice", MustGetObject[map[string]interface{}](doc, "user")["name"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"level": 3}, true}, doc["user"].(map[string]interface{})["roles"])
+	assert.Equal(t, []interface{}{"z", "b"}, doc["tags"])
+	assert.Equal(t, 1, doc["a.b"])
+	got, err := GetDate(doc, "when")
+	assert.NoError(t, err)
+	assert.True(t, date.Equal(got))
+
+	err = SetString(doc, "name.first", "x")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	assert.Equal(t, "name", err.(*InvalidTypeError).Prop)
+	err = SetString(doc, "user.name[0]", "x")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	err = SetString(doc, "fresh.list[2]", "x")
+	assert.IsType(t, &IndexError{}, err)
+	_, ok := doc["fresh"]
+	assert.False(t, ok)
+	for _, p := range []string{"", "a..b", "a[x]", "a[", "[0]", `a\`} {
+		assert.Error(t, SetPath(doc, p, 1), p)
+	}
+	assert.Error(t, SetPath(nil, "a", 1))
+}
+
+func TestPathOperations(t *testing.T) {
+	doc := map[string]interface{}{
+		"userName": "alice",
+		"meta":     map[string]interface{}{"owner": "bob", "tags": []interface{}{"a", "b", "c"}},
+		"legacy":   true,
+	}
+	changed, err := RenamePath(doc, "userName", "username")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "alice", doc["username"])
+	assert.NotContains(t, doc, "userName")
+
+	changed, err = MovePath(doc, "meta.owner", "owner")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "bob", doc["owner"])
+	assert.NotContains(t, doc["meta"], "owner")
+
+	changed, err = MovePath(doc, "meta.tags[0]", "meta.tags[]")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, []interface{}{"b", "c", "a"}, doc["meta"].(map[string]interface{})["tags"])
+
+	changed, err = CopyPath(doc, "meta", "backup")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.NoError(t, SetString(doc, "backup.extra", "x"))
+	assert.NotContains(t, doc["meta"], "extra")
+
+	changed, err = DeletePath(doc, "meta.tags[1]")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, []interface{}{"b", "a"}, doc["meta"].(map[string]interface{})["tags"])
+	changed, err = DeletePath(doc, "legacy", RequireSource)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	changed, err = DeletePath(doc, "legacy")
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	_, err = DeletePath(doc, "legacy", RequireSource)
+	assert.IsType(t, &MissingFieldError{}, err)
+	_, err = MovePath(doc, "nope.x", "y", RequireSource)
+	assert.IsType(t, &MissingFieldError{}, err)
+	_, err = CopyPath(doc, "nope", "y", RequireSource)
+	assert.IsType(t, &MissingFieldError{}, err)
+
+	_, err = MovePath(doc, "meta", "meta.inner")
+	assert.IsType(t, &ValidationError{}, err)
+	_, err = MovePath(doc, "owner", "username.first")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	assert.Equal(t, "bob", doc["owner"])
+	_, err = RenamePath(doc, "meta.tags[0]", "x")
+	assert.Error(t, err)
+
+	nested := map[string]interface{}{"a": map[string]interface{}{"b": map[string]interface{}{"b": 1}}}
+	changed, err = MovePath(nested, "a.b", "a")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, map[string]interface{}{"a": map[string]interface{}{"b": 1}}, nested)
+}
+
+func TestApplyPatch(t *testing.T) {
+	decode := func(s string) interface{} {
+		var v interface{}
+		assert.NoError(t, json.Unmarshal([]byte(s), &v))
+		return v
+	}
+	doc := decode(`{"foo": ["bar", "baz"], "a": {"b": {"c": 1}}, "k~/": 2}`).(map[string]interface{})
+	patch, err := ParsePatch(decode(`[
+		{"op": "add", "path": "/foo/1", "value": "qux"},
+		{"op": "add", "path": "/foo/-", "value": "end"},
+		{"op": "remove", "path": "/foo/0"},
+		{"op": "replace", "path": "/a/b/c", "value": 42},
+		{"op": "move", "from": "/a/b", "path": "/moved"},
+		{"op": "copy", "from": "/moved", "path": "/a/copy"},
+		{"op": "test", "path": "/moved/c", "value": 42},
+		{"op": "test", "path": "/k~0~1", "value": 2.0}
+	]`))
+	assert.NoError(t, err)
+	res, err := Apply(doc, patch)
+	assert.NoError(t, err)
+	assert.Equal(t, decode(`{"foo": ["qux", "baz", "end"], "a": {"copy": {"c": 42}}, "moved": {"c": 42}, "k~/": 2}`), res)
+	assert.Equal(t, []interface{}{"bar", "baz"}, doc["foo"])
+	assert.Equal(t, decode(`{"b": {"c": 1}}`), doc["a"])
+
+	failing := Patch{
+		{Op: "add", Path: "/x", Value: 1},
+		{Op: "test", Path: "/x", Value: 2},
+	}
+	_, err = Apply(doc, failing)
+	var pe *PatchError
+	assert.True(t, errors.As(err, &pe))
+	assert.Equal(t, 1, pe.Index)
+	assert.Equal(t, "/x", pe.Path)
+	assert.IsType(t, &ValidationError{}, pe.Cause)
+	assert.NotContains(t, doc, "x")
+
+	cases := []struct {
+		op    PatchOperation
+		cause error
+	}{
+		{PatchOperation{Op: "remove", Path: "/missing"}, &MissingFieldError{}},
+		{PatchOperation{Op: "replace", Path: "/missing", Value: 1}, &MissingFieldError{}},
+		{PatchOperation{Op: "add", Path: "/missing/x", Value: 1}, &MissingFieldError{}},
+		{PatchOperation{Op: "add", Path: "/foo/5", Value: 1}, &IndexError{}},
+		{PatchOperation{Op: "add", Path: "/foo/01", Value: 1}, &InvalidTypeError{}},
+		{PatchOperation{Op: "add", Path: "/k~0~1/x", Value: 1}, &InvalidTypeError{}},
+		{PatchOperation{Op: "move", From: "/a", Path: "/a/b/x"}, &ValidationError{}},
+		{PatchOperation{Op: "remove", Path: ""}, &ValidationError{}},
+		{PatchOperation{Op: "bogus", Path: "/a"}, &UnknownVariantError{}},
+	}
+	for _, c := range cases {
+		_, err := Apply(doc, Patch{c.op})
+		assert.True(t, errors.As(err, &pe), c.op)
+		assert.IsType(t, c.cause, pe.Cause, c.op)
+	}
+	_, err = Apply(doc, Patch{{Op: "add", Path: "a", Value: 1}})
+	assert.Error(t, err)
+
+	_, err = ParsePatch(decode(`[{"op": "add", "path": "/a"}]`))
+	assert.True(t, errors.As(err, &pe))
+	assert.IsType(t, &MissingFieldError{}, pe.Cause)
+	props := map[string]interface{}{"patch": decode(`[{"op": "remove", "path": "/a"}]`), "bad": "x"}
+	assert.Len(t, MustGetPatch(props, "patch"), 1)
+	_, err = GetPatch(props, "bad")
+	assert.IsType(t, &InvalidTypeError{}, err)
+	assert.Nil(t, GetPatchOrDefault(props, "missing", nil))
+}
+
+func TestMerge(t *testing.T) {
+	decode := func(s string) map[string]interface{} {
+		var v map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(s), &v))
+		return v
+	}
+	// Example from RFC 7386 section 3.
+	target := decode(`{"title": "Goodbye!", "author": {"givenName": "John", "familyName": "Doe"}, "tags": ["example", "sample"], "content": "This will be unchanged"}`)
+	patch := decode(`{"title": "Hello!", "phoneNumber": "+01-123-456-7890", "author": {"familyName": null}, "tags": ["example"]}`)
+	assert.Equal(t, decode(`{"title": "Hello!", "author": {"givenName": "John"}, "tags": ["example"], "content": "This will be unchanged", "phoneNumber": "+01-123-456-7890"}`), MergePatch(target, patch))
+	assert.Equal(t, "Doe", target["author"].(map[string]interface{})["familyName"])
+	assert.Equal(t, decode(`{"a": {"b": "c"}}`), MergePatch(decode(`{"a": "x"}`), decode(`{"a": {"b": "c", "d": null}}`)))
+
+	defaults := decode(`{"server": {"host": "localhost", "port": 80}, "plugins": [{"name": "a", "on": false}, {"name": "b"}], "tags": ["x"]}`)
+	file := decode(`{"server": {"port": 8080}, "plugins": [{"name": "a", "on": true}, {"name": "c"}], "tags": ["y"]}`)
+
+	res, err := DeepMerge(defaults, file)
+	assert.NoError(t, err)
+	assert.Equal(t, decode(`{"server": {"host": "localhost", "port": 8080}, "plugins": [{"name": "a", "on": true}, {"name": "c"}], "tags": ["y"]}`), res)
+
+	res, err = DeepMerge(defaults, file, MergeOptions{Arrays: AppendArrays})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"x", "y"}, res["tags"])
+
+	res, err = DeepMerge(defaults, file, MergeOptions{Arrays: MergeArraysByKey, Key: "name"})
+	assert.NoError(t, err)
+	assert.Equal(t, decode(`{"p": [{"name": "a", "on": true}, {"name": "b"}, {"name": "c"}]}`)["p"], res["plugins"])
+	assert.Equal(t, false, defaults["plugins"].([]interface{})[0].(map[string]interface{})["on"])
+
+	env := map[string]interface{}{"server": "remote", "plugins": []interface{}{map[string]interface{}{"name": "a", "on": "yes"}}}
+	res, err = DeepMerge(defaults, env)
+	assert.NoError(t, err)
+	assert.Equal(t, "remote", res["server"])
+
+	var conflicts []string
+	res, err = DeepMerge(defaults, env, MergeOptions{Arrays: MergeArraysByKey, Key: "name", OnConflict: func(path string, dst, src interface{}) (interface{}, error) {
+		conflicts = append(conflicts, path)
+		return dst, nil
+	}})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"plugins[0].on", "server"}, conflicts)
+	assert.Equal(t, "localhost", MustGetObject[map[string]interface{}](res, "server")["host"])
+
+	_, err = DeepMerge(defaults, env, MergeOptions{OnConflict: func(path string, dst, src interface{}) (interface{}, error) {
+		return nil, &InvalidTypeError{Prop: path, Expected: "object", Actual: src}
+	}})
+	assert.IsType(t, &InvalidTypeError{}, err)
+
+	// A nil base layer, top level or nested, is treated as empty.
+	assert.Equal(t, map[string]interface{}{"a": 1.0}, MergePatch(nil, decode(`{"a": 1, "b": null}`)))
+	assert.Equal(t, map[string]interface{}{"n": map[string]interface{}{"a": 1.0}},
+		MergePatch(map[string]interface{}{"n": map[string]interface{}(nil)}, decode(`{"n": {"a": 1}}`)))
+	res, err = DeepMerge(nil, file)
+	assert.NoError(t, err)
+	assert.Equal(t, file, res)
+	res, err = DeepMerge(map[string]interface{}{"server": map[string]interface{}(nil)}, file)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"port": 8080.0}, res["server"])
+
+	// Null keeps the destination value unless DeleteNulls is set.
+	nulls := decode(`{"server": {"host": null}, "tags": null}`)
+	res, err = DeepMerge(defaults, nulls)
+	assert.NoError(t, err)
+	assert.Equal(t, defaults, res)
+	res, err = DeepMerge(defaults, nulls, MergeOptions{DeleteNulls: true})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"port": 80.0}, res["server"])
+	assert.NotContains(t, res, "tags")
+	assert.Contains(t, defaults, "tags")
+}
+
+func TestDiff(t *testing.T) {
+	decode := func(s string) map[string]interface{} {
+		var v map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(s), &v))
+		return v
+	}
+	a := decode(`{"name": "svc", "port": 80, "tags": ["a", "b", "c"], "owner": {"team": "x"}, "old": true}`)
+	b := decode(`{"name": "svc", "port": 8080, "tags": ["a", "z"], "owner": {"team": "x", "lead": "y"}, "new/key": 1}`)
+	changes := Diff(a, b)
+	assert.Equal(t, []Change{
+		{Type: Added, Path: "/new~1key", New: 1.0},
+		{Type: Removed, Path: "/old", Old: true},
+		{Type: Added, Path: "/owner/lead", New: "y"},
+		{Type: Changed, Path: "/port", Old: 80.0, New: 8080.0},
+		{Type: Changed, Path: "/tags/1", Old: "b", New: "z"},
+		{Type: Removed, Path: "/tags/2", Old: "c"},
+	}, changes)
+	assert.Equal(t, "removed", changes[1].Type.String())
+	rendered, err := json.Marshal(DiffPatch(changes))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"op": "add", "path": "/new~1key", "value": 1},
+		{"op": "remove", "path": "/old"},
+		{"op": "add", "path": "/owner/lead", "value": "y"},
+		{"op": "replace", "path": "/port", "value": 8080},
+		{"op": "replace", "path": "/tags/1", "value": "z"},
+		{"op": "remove", "path": "/tags/2"}
+	]`, string(rendered))
+	nulled, err := json.Marshal(DiffPatch(Diff(map[string]interface{}{"a": 1}, map[string]interface{}{"a": nil, "b": nil})))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"op": "replace", "path": "/a", "value": null}, {"op": "add", "path": "/b", "value": null}]`, string(nulled))
+	patched, err := Apply(a, DiffPatch(changes))
+	assert.NoError(t, err)
+	assert.Equal(t, b, patched)
+	assert.Empty(t, Diff(a, a))
+
+	typed := map[string]interface{}{"n": 1, "list": []int{1, 2}}
+	assert.Len(t, Diff(typed, decode(`{"n": 1, "list": [1, 2]}`)), 3)
+	assert.Empty(t, Diff(typed, decode(`{"n": 1, "list": [1, 2]}`), DiffOptions{NumericEquivalence: true}))
+
+	before := decode(`{"users": [{"id": 1, "role": "admin"}, {"id": 2, "role": "dev"}, {"id": 3, "role": "ops"}]}`)
+	after := decode(`{"users": [{"id": 3, "role": "sre"}, {"id": 1, "role": "admin"}, {"id": 4, "role": "dev"}]}`)
+	keyed := Diff(before, after, DiffOptions{ArrayKey: "id"})
+	assert.Equal(t, []Change{
+		{Type: Removed, Path: "/users/1", Old: before["users"].([]interface{})[1]},
+		{Type: Changed, Path: "/users/1/role", Old: "ops", New: "sre"},
+		{Type: Added, Path: "/users/2", New: after["users"].([]interface{})[2]},
+	}, keyed)
+	patched, err = Apply(before, DiffPatch(keyed))
+	assert.NoError(t, err)
+	assert.Empty(t, Diff(patched, after, DiffOptions{ArrayKey: "id"}))
+	assert.Len(t, Diff(before, after), 6)
+}
+
+func TestDocumentOpsCopyAndCompare(t *testing.T) {
+	// Typed slices and maps are copied, not shared, by the document operations.
+	users := []map[string]interface{}{{"name": "a"}}
+	doc := map[string]interface{}{"users": users, "limits": map[string]int{"max": 1}}
+	patched, err := Apply(doc, Patch{
+		{Op: "copy", From: "/users", Path: "/backup"},
+		{Op: "replace", Path: "/limits", Value: map[string]int{"max": 2}},
+	})
+	assert.NoError(t, err)
+	patched["backup"].([]map[string]interface{})[0]["name"] = "b"
+	patched["users"].([]map[string]interface{})[0]["name"] = "c"
+	assert.Equal(t, "a", users[0]["name"])
+	assert.Equal(t, 1, doc["limits"].(map[string]int)["max"])
+
+	copied := map[string]interface{}{"users": users}
+	ok, err := CopyPath(copied, "users", "backup")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	copied["backup"].([]map[string]interface{})[0]["name"] = "b"
+	assert.Equal(t, "a", users[0]["name"])
+
+	merged, err := DeepMerge(doc, map[string]interface{}{"extra": true})
+	assert.NoError(t, err)
+	merged["users"].([]map[string]interface{})[0]["name"] = "b"
+	merged["limits"].(map[string]int)["max"] = 3
+	assert.Equal(t, "a", users[0]["name"])
+	assert.Equal(t, 1, doc["limits"].(map[string]int)["max"])
+	MergePatch(doc, map[string]interface{}{"extra": true})["users"].([]map[string]interface{})[0]["name"] = "b"
+	assert.Equal(t, "a", users[0]["name"])
+
+	// Numbers compare exactly across Go types, even beyond 2^53.
+	large := map[string]interface{}{"n": int64(1<<53 + 1)}
+	_, err = Apply(large, Patch{{Op: "test", Path: "/n", Value: float64(1 << 53)}})
+	assert.Error(t, err)
+	_, err = Apply(large, Patch{{Op: "test", Path: "/n", Value: json.Number("9007199254740993")}})
+	assert.NoError(t, err)
+	_, err = Apply(map[string]interface{}{"n": 1}, Patch{{Op: "test", Path: "/n", Value: 1.0}})
+	assert.NoError(t, err)
+	assert.False(t, DeepEqual(int64(1<<53+1), float64(1<<53)))
+	assert.True(t, DeepEqual(uint8(2), json.Number("2.0")))
+
+	byKey, err := DeepMerge(
+		map[string]interface{}{"items": []interface{}{
+			map[string]interface{}{"id": int64(1<<53 + 1), "v": "a"},
+		}},
+		map[string]interface{}{"items": []interface{}{
+			map[string]interface{}{"id": float64(1 << 53), "v": "b"},
+			map[string]interface{}{"id": json.Number("9007199254740993"), "w": "c"},
+		}},
+		MergeOptions{Arrays: MergeArraysByKey, Key: "id"},
+	)
+	assert.NoError(t, err)
+	items := byKey["items"].([]interface{})
+	assert.Len(t, items, 2)
+	assert.Equal(t, "a", items[0].(map[string]interface{})["v"])
+	assert.Equal(t, "c", items[0].(map[string]interface{})["w"])
+
+	changes := Diff(
+		map[string]interface{}{"list": []interface{}{map[string]interface{}{"id": 1, "v": "a"}}},
+		map[string]interface{}{"list": []interface{}{map[string]interface{}{"id": 1.0, "v": "b"}}},
+		DiffOptions{ArrayKey: "id", NumericEquivalence: true},
+	)
+	assert.Equal(t, []Change{{Type: Changed, Path: "/list/0/v", Old: "a", New: "b"}}, changes)
+}
+
+func TestDeepCopyAndEqual(t *testing.T) {
+	shared := map[string]interface{}{"host": "a"}
+	props := map[string]interface{}{
+		"cfg":   shared,
+		"tags":  []string{"x", "y"},
+		"nums":  []int{1, 2},
+		"flags": []bool{true},
+		"items": []interface{}{map[string]interface{}{"id": 1.0}},
+		"m":     map[string]int{"a": 1},
+	}
+	cfg := MustGetObject[map[string]interface{}](props, "cfg", GetOptions{Copy: true})
+	cfg["host"] = "b"
+	assert.Equal(t, "a", shared["host"])
+	tags, err := GetStringArray(props, "tags", GetOptions{Copy: true})
+	assert.NoError(t, err)
+	tags[0] = "z"
+	assert.Equal(t, []string{"x", "y"}, props["tags"])
+	MustGetNumberArray[int](props, "nums", GetOptions{Copy: true})[0] = 9
+	assert.Equal(t, []int{1, 2}, props["nums"])
+	GetBooleanArrayOrDefault(props, "flags", nil, GetOptions{Copy: true})[0] = false
+	assert.Equal(t, []bool{true}, props["flags"])
+	items := MustGetObjectArray[map[string]interface{}](props, "items", GetOptions{Copy: true})
+	items[0]["id"] = 2.0
+	assert.True(t, DeepEqual(1, props["items"].([]interface{})[0].(map[string]interface{})["id"]))
+	MustGetMap[string, int](props, "m", GetOptions{Copy: true})["a"] = 5
+	assert.Equal(t, 1, props["m"].(map[string]int)["a"])
+	// Ptr, pointer-array, date and legacy forms honour the option too.
+	copyOpt := GetOptions{Copy: true}
+	(*MustGetObjectPtr[map[string]interface{}](props, "cfg", copyOpt))["host"] = "p"
+	(*GetStringArrayPtrOrDefault(props, "tags", nil, copyOpt))[0] = "p"
+	*MustGetObjectArrayPtr[map[string]interface{}](props, "items", copyOpt) = nil
+	GetMapPropOrDefault[string, int](props, "m", nil, copyOpt)["a"] = 6
+	GetObjectPropOrDefault[map[string]interface{}](props, "cfg", nil, copyOpt)["host"] = "p"
+	(*GetObjectPropOrDefaultAllowNull[map[string]interface{}](props, "cfg", nil, copyOpt))["host"] = "p"
+	GetStringArrayPropOrDefault(props, "tags", nil, copyOpt)[1] = "p"
+	assert.Equal(t, "a", shared["host"])
+	assert.Equal(t, []string{"x", "y"}, props["tags"])
+	assert.Equal(t, 1, props["m"].(map[string]int)["a"])
+	strPtrs, err := GetStringPointerArray(props, "tags", copyOpt)
+	assert.NoError(t, err)
+	*strPtrs[0] = "p"
+	assert.Equal(t, []string{"x", "y"}, props["tags"])
+	numPtrs, err := GetNumberPointerArray[int](props, "nums", copyOpt)
+	assert.NoError(t, err)
+	*numPtrs[0] = 9
+	assert.Equal(t, []int{1, 2}, props["nums"])
+	itemPtrs, err := GetObjectPointerArray[map[string]interface{}](props, "items", copyOpt)
+	assert.NoError(t, err)
+	(*itemPtrs[0])["id"] = 3.0
+	assert.Equal(t, 1.0, props["items"].([]interface{})[0].(map[string]interface{})["id"])
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	dates := map[string]interface{}{"d": []time.Time{day}}
+	MustGetDateArray(dates, "d", copyOpt)[0] = time.Time{}
+	(*GetDateArrayPtrOrDefault(dates, "d", nil, copyOpt))[0] = time.Time{}
+	datePtrs, err := GetDatePointerArray(dates, "d", copyOpt)
+	assert.NoError(t, err)
+	*datePtrs[0] = time.Time{}
+	assert.Equal(t, []time.Time{day}, dates["d"])
+	boolPtrs, err := GetBooleanPointerArray(props, "flags", copyOpt)
+	assert.NoError(t, err)
+	*boolPtrs[0] = false
+	assert.Equal(t, []bool{true}, props["flags"])
+
+	// Without the option results still share state.
+	MustGetObject[map[string]interface{}](props, "cfg")["host"] = "c"
+	assert.Equal(t, "c", shared["host"])
+
+	cp := DeepCopy(props)
+	cp["tags"].([]string)[1] = "q"
+	cp["items"].([]interface{})[0].(map[string]interface{})["id"] = 5
+	cp["m"].(map[string]int)["a"] = 7
+	assert.Equal(t, "y", props["tags"].([]string)[1])
+	assert.Equal(t, 1, props["m"].(map[string]int)["a"])
+	assert.Nil(t, DeepCopy[map[string]interface{}](nil))
+	arrDoc := map[string]interface{}{"a": [1]map[string]interface{}{{"k": "v"}}, "n": [2]int{1, 2}}
+	arrCopy := DeepCopy(arrDoc)
+	arrCopy["a"].([1]map[string]interface{})[0]["k"] = "w"
+	assert.Equal(t, "v", arrDoc["a"].([1]map[string]interface{})[0]["k"])
+	assert.Equal(t, [2]int{1, 2}, arrCopy["n"])
+
+	assert.True(t, DeepEqual(
+		map[string]interface{}{"a": int64(1), "b": []int{1, 2}, "c": map[string]string{"k": "v"}, "d": json.Number("2.50")},
+		map[string]interface{}{"a": 1.0, "b": []interface{}{json.Number("1"), uint8(2)}, "c": map[string]interface{}{"k": "v"}, "d": float32(2.5)},
+	))
+	assert.False(t, DeepEqual(int64(1<<53+1), float64(1<<53)))
+	assert.False(t, DeepEqual(map[string]interface{}{"a": 1}, map[string]interface{}{"a": "1"}))
+	assert.False(t, DeepEqual([]int{1}, []int{1, 2}))
+
+	type level int
+	n := 3
+	norm := Normalize(map[string]interface{}{
+		"i":   7,
+		"l":   level(2),
+		"num": json.Number("1.5"),
+		"arr": []int32{1},
+		"m":   map[int]string{4: "x"},
+		"p":   &n,
+		"nil": []string(nil),
+	})
+	assert.Equal(t, map[string]interface{}{
+		"i":   7.0,
+		"l":   2.0,
+		"num": 1.5,
+		"arr": []interface{}{1.0},
+		"m":   map[string]interface{}{"4": "x"},
+		"p":   3.0,
+		"nil": nil,
+	}, norm)
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	decode := func(s string) interface{} {
+		var v interface{}
+		assert.NoError(t, json.Unmarshal([]byte(s), &v))
+		return v
+	}
+	// RFC 8785 section 3.2.2.
+	out, err := CanonicalJSON(decode(`{
+		"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+		"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+		"literals": [null, true, false]
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`, string(out))
+
+	// RFC 8785 section 3.2.3, members sorted by UTF-16 code units.
+	out, err = CanonicalJSON(decode(`{
+		"\u20ac": "Euro Sign",
+		"\r": "Carriage Return",
+		"\ufb33": "Hebrew Letter Dalet With Dagesh",
+		"1": "One",
+		"\ud83d\ude00": "Emoji: Grinning Face",
+		"\u0080": "Control",
+		"\u00f6": "Latin Small Letter O With Diaeresis"
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"ö\":\"Latin Small Letter O With Diaeresis\",\"€\":\"Euro Sign\",\"😀\":\"Emoji: Grinning Face\",\"דּ\":\"Hebrew Letter Dalet With Dagesh\"}", string(out))
+
+	// RFC 8785 appendix B.
+	numbers := map[uint64]string{
+		0x0000000000000000: "0",
+		0x8000000000000000: "0",
+		0x0000000000000001: "5e-324",
+		0x8000000000000001: "-5e-324",
+		0x7fefffffffffffff: "1.7976931348623157e+308",
+		0xffefffffffffffff: "-1.7976931348623157e+308",
+		0x4340000000000000: "9007199254740992",
+		0xc340000000000000: "-9007199254740992",
+		0x4430000000000000: "295147905179352830000",
+		0x44b52d02c7e14af5: "9.999999999999997e+22",
+		0x44b52d02c7e14af6: "1e+23",
+		0x44b52d02c7e14af7: "1.0000000000000001e+23",
+		0x444b1ae4d6e2ef4e: "999999999999999700000",
+		0x444b1ae4d6e2ef4f: "999999999999999900000",
+		0x444b1ae4d6e2ef50: "1e+21",
+		0x3eb0c6f7a0b5ed8c: "9.999999999999997e-7",
+		0x3eb0c6f7a0b5ed8d: "0.000001",
+		0x41b3de4355555553: "333333333.3333332",
+		0x41b3de4355555554: "333333333.33333325",
+		0x41b3de4355555555: "333333333.3333333",
+		0x41b3de4355555556: "333333333.3333334",
+		0x41b3de4355555557: "333333333.33333343",
+		0xbecbf647612f3696: "-0.0000033333333333333333",
+		0x43143ff3c1cb0959: "1424953923781206.2",
+	}
+	for bits, want := range numbers {
+		out, err := CanonicalJSON(math.Float64frombits(bits))
+		assert.NoError(t, err)
+		assert.Equal(t, want, string(out), "%016x", bits)
+	}
+	for _, bad := range []interface{}{math.NaN(), math.Inf(1), int64(1<<53 + 1), "\xff", map[string]interface{}{"a": []interface{}{math.Inf(-1)}}} {
+		_, err := CanonicalJSON(bad)
+		assert.IsType(t, &ValidationError{}, err)
+	}
+	_, err = CanonicalJSON(map[string]interface{}{"f": func() {}})
+	assert.IsType(t, &InvalidTypeError{}, err)
+
+	a := map[string]interface{}{"b": []int{1, 2}, "a": map[string]int{"x": 1}, "when": time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), "raw": []byte("hi")}
+	b := decode(`{"a": {"x": 1.0}, "b": [1, 2e0], "when": "2024-01-02T00:00:00Z", "raw": "aGk="}`)
+	ha, err := Hash(a, sha256.New())
+	assert.NoError(t, err)
+	hb, err := Hash(b, sha256.New())
+	assert.NoError(t, err)
+	assert.Equal(t, ha, hb)
+	hc, _ := Hash(map[string]interface{}{"a": 2}, sha256.New())
+	assert.NotEqual(t, ha, hc)
+}
+
+func TestFlatten(t *testing.T) {
+	doc := map[string]interface{}{
+		"db":    map[string]interface{}{"host": "localhost", "port": 5432},
+		"users": []interface{}{map[string]interface{}{"name": "a"}, map[string]interface{}{"name": "b"}},
+		"a.b":   1,
+		"10":    "digits",
+		"empty": map[string]interface{}{},
+		"none":  []interface{}{},
+	}
+	flat := Flatten(doc, ".")
+	assert.Equal(t, map[string]interface{}{
+		"db.host":      "localhost",
+		"db.port":      5432,
+		"users.0.name": "a",
+		"users.1.name": "b",
+		`a\.b`:         1,
+		`\10`:          "digits",
+		"empty":        map[string]interface{}{},
+		"none":         []interface{}{},
+	}, flat)
+	back, err := Unflatten(flat, ".")
+	assert.NoError(t, err)
+	assert.Equal(t, doc, back)
+
+	bracket := Flatten(doc, "", BracketIndex)
+	assert.Equal(t, "a", bracket["users[0].name"])
+	assert.Equal(t, "digits", bracket["10"])
+	back, err = Unflatten(bracket, "", BracketIndex)
+	assert.NoError(t, err)
+	assert.Equal(t, doc, back)
+
+	env := map[string]interface{}{"APP__DB__HOST": "h", "APP__TAGS__1": "y", "APP__TAGS__0": "x", "A_": 1}
+	nested, err := Unflatten(env, "__")
+	assert.NoError(t, err)
+	app := MustGetObject[map[string]interface{}](nested, "APP")
+	assert.Equal(t, "h", MustGetObject[map[string]interface{}](app, "DB")["HOST"])
+	assert.Equal(t, []string{"x", "y"}, MustGetStringArray(app, "TAGS"))
+	assert.Equal(t, map[string]interface{}{`A\_`: 1}, Flatten(map[string]interface{}{"A_": 1}, "__"))
+	round, err := Unflatten(Flatten(map[string]interface{}{"a_": map[string]interface{}{"_b": 1}}, "__"), "__")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a_": map[string]interface{}{"_b": 1}}, round)
+
+	_, err = Unflatten(map[string]interface{}{"a": 1, "a.b": 2}, ".")
+	assert.Equal(t, &KeyCollisionError{Key: "a.b", Other: "a"}, err)
+	_, err = Unflatten(map[string]interface{}{"a.b.c": 1, "a.b": 2}, ".")
+	assert.IsType(t, &KeyCollisionError{}, err)
+	_, err = Unflatten(map[string]interface{}{`a\b`: 1, "ab": 2}, ".")
+	assert.IsType(t, &KeyCollisionError{}, err)
+	_, err = Unflatten(map[string]interface{}{"a.1": 1}, ".")
+	assert.IsType(t, &IndexError{}, err)
+	_, err = Unflatten(map[string]interface{}{"a.0": 1, "a.x": 2}, ".")
+	assert.Equal(t, &KeyCollisionError{Key: "a.x", Other: "a.0"}, err)
+	_, err = Unflatten(map[string]interface{}{"a.0": 1, "a.1": 2, "a.b": 3}, ".")
+	assert.IsType(t, &KeyCollisionError{}, err)
+	_, err = Unflatten(map[string]interface{}{"a[0].b": 1, "a.c.d": 2}, ".", BracketIndex)
+	assert.IsType(t, &KeyCollisionError{}, err)
+	for _, k := range []string{"a..b", `a\`, "a[x]", "a[0]b", "[0]"} {
+		_, err = Unflatten(map[string]interface{}{k: 1}, ".", BracketIndex)
+		assert.Error(t, err, k)
+	}
+	_, err = Unflatten(map[string]interface{}{"0": 1}, ".")
+	assert.IsType(t, &InvalidTypeError{}, err)
+}
+
+func TestWalk(t *testing.T) {
+	doc := map[string]interface{}{
+		"name":  "svc",
+		"ports": []int{80, 443},
+		"meta":  map[string]interface{}{"a/b": true, "secret": map[string]interface{}{"k": "v"}},
+		"raw":   []byte("x"),
+	}
+	var paths []string
+	err := Walk(doc, func(path string, value interface{}) error {
+		paths = append(paths, path)
+		if path == "/meta/secret" {
+			return SkipSubtree
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "/meta", "/meta/a~1b", "/meta/secret", "/name", "/ports", "/ports/0", "/ports/1", "/raw"}, paths)
+
+	paths = nil
+	err = Walk(doc, func(path string, value interface{}) error {
+		paths = append(paths, path)
+		if path == "/name" {
+			return StopWalk
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "/name", paths[len(paths)-1])
+
+	boom := errors.New("boom")
+	err = Walk(doc, func(path string, value interface{}) error {
+		if path == "/ports/1" {
+			return boom
+		}
+		return nil
+	})
+	assert.Equal(t, boom, err)
+
+	res, err := Transform(doc, func(path string, value interface{}) (interface{}, error) {
+		switch v := value.(type) {
+		case string:
+			return strings.ToUpper(v), nil
+		case int:
+			return v + 1, nil
+		}
+		if path == "/meta/secret" {
+			return "[redacted]", SkipSubtree
+		}
+		return value, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "SVC", res["name"])
+	assert.Equal(t, []interface{}{81, 444}, res["ports"])
+	assert.Equal(t, "[redacted]", res["meta"].(map[string]interface{})["secret"])
+	assert.Equal(t, "svc", doc["name"])
+	assert.Equal(t, []int{80, 443}, doc["ports"])
+
+	res, err = Transform(doc, func(path string, value interface{}) (interface{}, error) {
+		if path == "/meta" {
+			return map[string]interface{}{"n": 1}, StopWalk
+		}
+		return value, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"n": 1}, res["meta"])
+	assert.Equal(t, "svc", res["name"])
+
+	_, err = Transform(doc, func(path string, value interface{}) (interface{}, error) {
+		if path == "" {
+			return "not an object", nil
+		}
+		return value, nil
+	})
+	assert.IsType(t, &InvalidTypeError{}, err)
+	_, err = Transform(doc, func(path string, value interface{}) (interface{}, error) {
+		return nil, boom
+	})
+	assert.Equal(t, boom, err)
+}
+
+func TestKeyMatching(t *testing.T) {
+	props := map[string]interface{}{
+		"userId":    "u1",
+		"UserName":  "alice",
+		"user-age":  30,
+		"HTTPPort":  8080,
+		"status":    "a",
+		"Status":    "b",
+		"team_name": "x",
+		"teamName":  "y",
+	}
+	key, err := FindKey(props, "user_id", ConventionInsensitiveKeys)
+	assert.NoError(t, err)
+	assert.Equal(t, "userId", key)
+	_, err = FindKey(props, "user_id", CaseInsensitiveKeys)
+	assert.IsType(t, &MissingFieldError{}, err)
+	_, err = FindKey(props, "userid", ExactKeys)
+	assert.IsType(t, &MissingFieldError{}, err)
+	key, err = FindKey(props, "status", CaseInsensitiveKeys)
+	assert.NoError(t, err)
+	assert.Equal(t, "status", key)
+	_, err = FindKey(props, "STATUS", CaseInsensitiveKeys)
+	assert.Equal(t, &AmbiguousKeyError{Prop: "STATUS", Matches: []string{"Status", "status"}}, err)
+	_, err = FindKey(props, "TeamName", ConventionInsensitiveKeys)
+	assert.IsType(t, &AmbiguousKeyError{}, err)
+
+	name, err := GetMatching(props, "user_name", ConventionInsensitiveKeys, GetString)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", name)
+	assert.Equal(t, 30, MustGetMatching(props, "userAge", ConventionInsensitiveKeys, GetNumber[int]))
+	assert.Equal(t, 8080, MustGetMatching(props, "http_port", ConventionInsensitiveKeys, GetNumber[int]))
+	_, err = GetMatching(props, "missing", ConventionInsensitiveKeys, GetString)
+	assert.Equal(t, &MissingFieldError{Prop: "missing"}, err)
+	assert.Equal(t, "d", GetMatchingOrDefault(props, "STATUS", CaseInsensitiveKeys, GetString, "d"))
+
+	// Getters with options work through a closure or a MatchKeys view.
+	loose := map[string]interface{}{
+		"role_ids": []interface{}{"a", "b"},
+		"Profile":  map[string]interface{}{"bio": "x"},
+		"Status":   "on",
+		"status":   "off",
+	}
+	roles, err := GetMatching(loose, "roleIds", ConventionInsensitiveKeys, func(m map[string]interface{}, k string) ([]string, error) {
+		return GetStringArray(m, k)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, roles)
+	view, err := MatchKeys(loose, ConventionInsensitiveKeys, "roleIds", "profile", "missing")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, MustGetStringArray(view, "roleIds", GetOptions{Copy: true}))
+	profile := MustGetObject[map[string]interface{}](view, "profile", GetOptions{Copy: true})
+	profile["bio"] = "y"
+	assert.Equal(t, "x", loose["Profile"].(map[string]interface{})["bio"])
+	assert.Equal(t, "off", MustGetString(view, "status"))
+	_, err = GetObject[map[string]interface{}](view, "missing")
+	assert.IsType(t, &MissingFieldError{}, err)
+	assert.NotContains(t, loose, "roleIds")
+	_, err = MatchKeys(loose, CaseInsensitiveKeys, "STATUS")
+	assert.IsType(t, &AmbiguousKeyError{}, err)
+
+	for key, want := range map[string][4]string{
+		"userId":      {"userId", "UserId", "user_id", "user-id"},
+		"UserID":      {"userId", "UserId", "user_id", "user-id"},
+		"user_id":     {"userId", "UserId", "user_id", "user-id"},
+		"HTTPServer":  {"httpServer", "HttpServer", "http_server", "http-server"},
+		"base64Value": {"base64Value", "Base64Value", "base64_value", "base64-value"},
+		"_":           {"_", "_", "_", "_"},
+	} {
+		for i, c := range []KeyConvention{CamelCase, PascalCase, SnakeCase, KebabCase} {
+			assert.Equal(t, want[i], ConvertKey(key, c), key)
+		}
+	}
+
+	doc := map[string]interface{}{
+		"userId":  1,
+		"Profile": map[string]interface{}{"first-name": "a", "tags": []string{"KeepMe"}},
+		"items":   []interface{}{map[string]interface{}{"itemID": 2}},
+	}
+	res, err := RewriteKeys(doc, SnakeCase)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"user_id": 1,
+		"profile": map[string]interface{}{"first_name": "a", "tags": []string{"KeepMe"}},
+		"items":   []interface{}{map[string]interface{}{"item_id": 2}},
+	}, res)
+	assert.Contains(t, doc, "userId")
+	type label string
+	typed, err := RewriteKeys(map[string]interface{}{
+		"list":   []map[string]interface{}{{"userId": 1}, nil},
+		"counts": map[string]int{"pageViews": 3},
+		"labels": map[label][]map[string]interface{}{"TeamName": {{"memberId": 1}}},
+		"byId":   map[int]map[string]interface{}{7: {"firstName": "a"}},
+	}, SnakeCase)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"list":   []map[string]interface{}{{"user_id": 1}, nil},
+		"counts": map[string]int{"page_views": 3},
+		"labels": map[label][]map[string]interface{}{"team_name": {{"member_id": 1}}},
+		"by_id":  map[int]map[string]interface{}{7: {"first_name": "a"}},
+	}, typed)
+	_, err = RewriteKeys(map[string]interface{}{"m": map[string]int{"a_b": 1, "aB": 2}}, SnakeCase)
+	assert.IsType(t, &KeyCollisionError{}, err)
+	_, err = RewriteKeys(map[string]interface{}{"a": map[string]interface{}{"user_id": 1, "userId": 2}}, CamelCase)
+	assert.Equal(t, &KeyCollisionError{Key: "user_id", Other: "userId"}, err)
+}
+
+func TestPatchMarshal(t *testing.T) {
+	patch := Patch{
+		{Op: "add", Path: "/a", Value: nil},
+		{Op: "remove", Path: "/b", Value: 1},
+		{Op: "replace", Path: "/c", Value: 2},
+		{Op: "move", From: "", Path: "/d"},
+		{Op: "copy", From: "/e", Path: "/f"},
+		{Op: "test", Path: "/g", Value: []interface{}{}},
+	}
+	out, err := json.Marshal(patch)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"op": "add", "path": "/a", "value": null},
+		{"op": "remove", "path": "/b"},
+		{"op": "replace", "path": "/c", "value": 2},
+		{"op": "move", "from": "", "path": "/d"},
+		{"op": "copy", "from": "/e", "path": "/f"},
+		{"op": "test", "path": "/g", "value": []}
+	]`, string(out))
+
+	var raw interface{}
+	assert.NoError(t, json.Unmarshal(out, &raw))
+	parsed, err := ParsePatch(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, Patch{
+		{Op: "add", Path: "/a"},
+		{Op: "remove", Path: "/b"},
+		{Op: "replace", Path: "/c", Value: 2.0},
+		{Op: "move", Path: "/d"},
+		{Op: "copy", From: "/e", Path: "/f"},
+		{Op: "test", Path: "/g", Value: []interface{}{}},
+	}, parsed)
+}
@@ -0,0 +1,391 @@
+package go_objectutils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is a Semantic Version 2.0.0 such as "1.4.2-rc.1+build.5".
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease []string
+	Build      []string
+}
+
+var (
+	versionPattern    = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`)
+	partialPattern    = regexp.MustCompile(`^v?(0|[1-9]\d*)(?:\.(0|[1-9]\d*|[xX*]))?(?:\.(0|[1-9]\d*|[xX*]))?(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`)
+	numericIdentifier = regexp.MustCompile(`^\d+$`)
+)
+
+// ParseVersion parses a semantic version, allowing an optional leading "v".
+func ParseVersion(s string) (Version, error) {
+	m := versionPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, fmt.Errorf("cannot parse '%s' as a semantic version", s)
+	}
+	var v Version
+	var err error
+	if v.Major, err = strconv.ParseUint(m[1], 10, 64); err != nil {
+		return Version{}, err
+	}
+	if v.Minor, err = strconv.ParseUint(m[2], 10, 64); err != nil {
+		return Version{}, err
+	}
+	if v.Patch, err = strconv.ParseUint(m[3], 10, 64); err != nil {
+		return Version{}, err
+	}
+	if m[4] != "" {
+		v.Prerelease = strings.Split(m[4], ".")
+		for _, id := range v.Prerelease {
+			if len(id) > 1 && id[0] == '0' && numericIdentifier.MatchString(id) {
+				return Version{}, fmt.Errorf("pre-release identifier '%s' in '%s' has a leading zero", id, s)
+			}
+		}
+	}
+	if m[5] != "" {
+		v.Build = strings.Split(m[5], ".")
+	}
+	return v, nil
+}
+
+// String returns the canonical form of the version, without a "v" prefix.
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if len(v.Build) > 0 {
+		s += "+" + strings.Join(v.Build, ".")
+	}
+	return s
+}
+
+// Compare returns -1, 0 or +1 according to SemVer precedence. Build metadata is ignored.
+func (v Version) Compare(other Version) int {
+	for _, pair := range [][2]uint64{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(v.Prerelease) == 0 && len(other.Prerelease) == 0:
+		return 0
+	case len(v.Prerelease) == 0:
+		return 1
+	case len(other.Prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.Prerelease) && i < len(other.Prerelease); i++ {
+		if c := comparePrerelease(v.Prerelease[i], other.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(v.Prerelease), len(other.Prerelease))
+}
+
+// comparePrerelease compares single pre-release identifiers: numeric identifiers compare
+// numerically and sort before alphanumeric ones, which compare lexically.
+func comparePrerelease(a, b string) int {
+	aNum, bNum := numericIdentifier.MatchString(a), numericIdentifier.MatchString(b)
+	switch {
+	case aNum && bNum:
+		if len(a) != len(b) {
+			return compareInt(len(a), len(b))
+		}
+		return strings.Compare(a, b)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// LessThan reports whether v has lower precedence than other.
+func (v Version) LessThan(other Version) bool {
+	return v.Compare(other) < 0
+}
+
+// Equal reports whether v and other have the same precedence.
+func (v Version) Equal(other Version) bool {
+	return v.Compare(other) == 0
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *Version) UnmarshalText(data []byte) error {
+	parsed, err := ParseVersion(string(data))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// versionComparison is a single "op version" term of a constraint.
+type versionComparison struct {
+	op      string
+	version Version
+}
+
+func (c versionComparison) matches(v Version) bool {
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	}
+	return cmp <= 0
+}
+
+// VersionConstraint is a set of version requirements such as ">=1.2, <2.0" or "^1.4 || ~2.1.0".
+// Comma or space separated comparisons must all match; "||" separates alternatives.
+type VersionConstraint struct {
+	expression string
+	anyOf      [][]versionComparison
+}
+
+// ParseVersionConstraint parses a version constraint. Supported operators are =, !=, >, >=, <, <=,
+// ~ (patch-level changes) and ^ (changes that do not modify the left-most non-zero component).
+// Partial versions such as "1.2" or "1.x" are completed with zeros.
+func ParseVersionConstraint(s string) (*VersionConstraint, error) {
+	c := &VersionConstraint{expression: s}
+	for _, alternative := range strings.Split(s, "||") {
+		terms := strings.Fields(strings.ReplaceAll(alternative, ",", " "))
+		if len(terms) == 0 {
+			return nil, fmt.Errorf("constraint '%s' has an empty alternative", s)
+		}
+		var all []versionComparison
+		for i := 0; i < len(terms); i++ {
+			term := terms[i]
+			// Allow a space between the operator and the version, e.g. ">= 1.2".
+			if strings.TrimLeft(term, "=!<>~^") == "" && i+1 < len(terms) {
+				term += terms[i+1]
+				i++
+			}
+			comparisons, err := parseVersionTerm(term)
+			if err != nil {
+				return nil, fmt.Errorf("constraint '%s': %w", s, err)
+			}
+			all = append(all, comparisons...)
+		}
+		c.anyOf = append(c.anyOf, all)
+	}
+	return c, nil
+}
+
+// parseVersionTerm expands a single operator and partial version into comparisons.
+func parseVersionTerm(term string) ([]versionComparison, error) {
+	op := ""
+	for _, candidate := range []string{">=", "<=", "!=", "=", ">", "<", "~", "^"} {
+		if strings.HasPrefix(term, candidate) {
+			op = candidate
+			break
+		}
+	}
+	text := strings.TrimPrefix(term, op)
+	m := partialPattern.FindStringSubmatch(text)
+	if m == nil {
+		return nil, fmt.Errorf("cannot parse '%s' as a version", text)
+	}
+	var v Version
+	v.Major, _ = strconv.ParseUint(m[1], 10, 64)
+	// precision counts the components given explicitly: 1, 2 or 3.
+	precision := 1
+	if m[2] != "" && !strings.ContainsAny(m[2], "xX*") {
+		v.Minor, _ = strconv.ParseUint(m[2], 10, 64)
+		precision = 2
+		if m[3] != "" && !strings.ContainsAny(m[3], "xX*") {
+			v.Patch, _ = strconv.ParseUint(m[3], 10, 64)
+			precision = 3
+		}
+	}
+	if m[4] != "" {
+		v.Prerelease = strings.Split(m[4], ".")
+	}
+	// nextAt returns the lowest version above v when incrementing the component at position p.
+	nextAt := func(p int) Version {
+		switch p {
+		case 1:
+			return Version{Major: v.Major + 1}
+		case 2:
+			return Version{Major: v.Major, Minor: v.Minor + 1}
+		}
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	}
+	switch op {
+	case "", "=":
+		if precision == 3 {
+			return []versionComparison{{"=", v}}, nil
+		}
+		return []versionComparison{{">=", v}, {"<", nextAt(precision)}}, nil
+	case "~":
+		if precision == 1 {
+			return []versionComparison{{">=", v}, {"<", nextAt(1)}}, nil
+		}
+		return []versionComparison{{">=", v}, {"<", nextAt(2)}}, nil
+	case "^":
+		switch {
+		case v.Major != 0 || precision == 1:
+			return []versionComparison{{">=", v}, {"<", nextAt(1)}}, nil
+		case v.Minor != 0 || precision == 2:
+			return []versionComparison{{">=", v}, {"<", nextAt(2)}}, nil
+		}
+		return []versionComparison{{">=", v}, {"<", nextAt(3)}}, nil
+	case ">":
+		if precision < 3 {
+			return []versionComparison{{">=", nextAt(precision)}}, nil
+		}
+	case "<=":
+		if precision < 3 {
+			return []versionComparison{{"<", nextAt(precision)}}, nil
+		}
+	}
+	return []versionComparison{{op, v}}, nil
+}
+
+// Check reports whether v satisfies the constraint.
+func (c *VersionConstraint) Check(v Version) bool {
+	for _, all := range c.anyOf {
+		ok := true
+		for _, comparison := range all {
+			if !comparison.matches(v) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the original constraint expression.
+func (c *VersionConstraint) String() string {
+	return c.expression
+}
+
+// convertToVersion converts a Version or version string.
+func convertToVersion(val interface{}) (Version, error) {
+	switch v := val.(type) {
+	case Version:
+		return v, nil
+	case string:
+		return ParseVersion(v)
+	}
+	return Version{}, fmt.Errorf("cannot convert %T to version", val)
+}
+
+// GetVersion retrieves a semantic version property such as "v1.4.2-rc.1".
+func GetVersion(props map[string]interface{}, prop string) (Version, error) {
+	if props == nil {
+		return Version{}, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return Version{}, &MissingFieldError{Prop: prop}
+	}
+	if v, err := convertToVersion(val); err == nil {
+		return v, nil
+	} else {
+		return Version{}, &InvalidTypeError{Prop: prop, Expected: "semantic version", Actual: val, Cause: err}
+	}
+}
+
+// MustGetVersion retrieves a semantic version property or panics.
+func MustGetVersion(props map[string]interface{}, prop string) Version {
+	val, err := GetVersion(props, prop)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetVersionOrDefault retrieves a semantic version property or returns a default value.
+func GetVersionOrDefault(props map[string]interface{}, prop string, defaultValue Version) Version {
+	val, err := GetVersion(props, prop)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// GetVersionPtr retrieves a semantic version property as a pointer.
+func GetVersionPtr(props map[string]interface{}, prop string) (*Version, error) {
+	val, err := GetVersion(props, prop)
+	if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+// GetVersionSatisfying retrieves a semantic version property and checks it against constraint.
+// Versions outside the constraint return a *ValidationError.
+func GetVersionSatisfying(props map[string]interface{}, prop string, constraint *VersionConstraint) (Version, error) {
+	v, err := GetVersion(props, prop)
+	if err != nil {
+		return Version{}, err
+	}
+	if !constraint.Check(v) {
+		return Version{}, &ValidationError{Prop: prop, Value: props[prop], Reason: fmt.Sprintf("version %s does not satisfy '%s'", v, constraint)}
+	}
+	return v, nil
+}
+
+// GetVersionConstraint retrieves a version constraint property such as ">=1.2, <2.0".
+func GetVersionConstraint(props map[string]interface{}, prop string) (*VersionConstraint, error) {
+	if props == nil {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	val, ok := props[prop]
+	if !ok {
+		return nil, &MissingFieldError{Prop: prop}
+	}
+	s, ok := val.(string)
+	if !ok {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "version constraint", Actual: val}
+	}
+	if c, err := ParseVersionConstraint(s); err == nil {
+		return c, nil
+	} else {
+		return nil, &InvalidTypeError{Prop: prop, Expected: "version constraint", Actual: val, Cause: err}
+	}
+}
+
+// MustGetVersionConstraint retrieves a version constraint property or panics.
+func MustGetVersionConstraint(props map[string]interface{}, prop string) *VersionConstraint {
+	val, err := GetVersionConstraint(props, prop)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetVersionConstraintOrDefault retrieves a version constraint property or returns a default value.
+func GetVersionConstraintOrDefault(props map[string]interface{}, prop string, defaultValue *VersionConstraint) *VersionConstraint {
+	val, err := GetVersionConstraint(props, prop)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
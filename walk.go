@@ -0,0 +1,121 @@
+package go_objectutils
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+)
+
+// SkipSubtree may be returned by a Walk visitor or Transform function to skip the children of
+// the current value.
+var SkipSubtree = errors.New("skip subtree")
+
+// StopWalk may be returned by a Walk visitor or Transform function to end the walk early
+// without reporting an error.
+var StopWalk = errors.New("stop walk")
+
+// Visitor is called by Walk with the JSON Pointer path of each value.
+type Visitor func(path string, value interface{}) error
+
+// Walk visits doc and every object, array and scalar below it in depth-first order, parents
+// before children. The root has the path "". Object members are visited in sorted key order.
+// Returning SkipSubtree skips the children of the value and StopWalk ends the walk; any other
+// error ends the walk and is returned.
+func Walk(doc map[string]interface{}, visitor Visitor) error {
+	err := walkValue(nil, doc, visitor)
+	if err == StopWalk {
+		return nil
+	}
+	return err
+}
+
+func walkValue(path []string, v interface{}, visitor Visitor) error {
+	if err := visitor(formatPointer(path), v); err != nil {
+		if err == SkipSubtree {
+			return nil
+		}
+		return err
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		for _, k := range sortedKeys(m) {
+			if err := walkValue(append(path[:len(path):len(path)], k), m[k], visitor); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if arr, ok := walkArray(v); ok {
+		for i, e := range arr {
+			if err := walkValue(append(path[:len(path):len(path)], strconv.Itoa(i)), e, visitor); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Transform walks a copy of doc like Walk, replacing each value with the result of fn before its
+// children are visited, so children of a replacement value are themselves transformed.
+// Returning SkipSubtree keeps the result without visiting its children, and StopWalk keeps the
+// result and leaves the rest of the document unchanged. doc itself is never modified, and typed
+// slices in the result become []interface{}.
+func Transform(doc map[string]interface{}, fn func(path string, value interface{}) (interface{}, error)) (map[string]interface{}, error) {
+	res, err := transformValue(nil, copyValue(doc), fn)
+	if err != nil && err != StopWalk {
+		return nil, err
+	}
+	m, ok := res.(map[string]interface{})
+	if !ok {
+		return nil, &InvalidTypeError{Prop: "", Expected: "object", Actual: res}
+	}
+	return m, nil
+}
+
+func transformValue(path []string, v interface{}, fn func(path string, value interface{}) (interface{}, error)) (interface{}, error) {
+	v, err := fn(formatPointer(path), v)
+	if err != nil {
+		if err == SkipSubtree {
+			return v, nil
+		}
+		return v, err
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		for _, k := range sortedKeys(m) {
+			child, err := transformValue(append(path[:len(path):len(path)], k), m[k], fn)
+			m[k] = child
+			if err != nil {
+				return m, err
+			}
+		}
+		return m, nil
+	}
+	if arr, ok := walkArray(v); ok {
+		for i, e := range arr {
+			child, err := transformValue(append(path[:len(path):len(path)], strconv.Itoa(i)), e, fn)
+			arr[i] = child
+			if err != nil {
+				return arr, err
+			}
+		}
+		return arr, nil
+	}
+	return v, nil
+}
+
+// walkArray returns the elements of v when it is an array. Byte slices are treated as scalars.
+func walkArray(v interface{}) ([]interface{}, bool) {
+	if _, ok := v.([]byte); ok {
+		return nil, false
+	}
+	return toInterfaceSlice(v)
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}